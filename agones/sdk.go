@@ -0,0 +1,191 @@
+// Package agones talks to the Agones SDK sidecar through its REST gateway,
+// the same calls the Go SDK makes over gRPC. It does not import
+// agones.dev/agones/sdks/go: that module brings in all of Agones and its
+// Kubernetes dependencies for a handful of HTTP calls.
+package agones
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	// PortEnv 由 Agones 注入，sidecar REST 端口
+	PortEnv     = "AGONES_SDK_HTTP_PORT"
+	DefaultPort = "9358"
+)
+
+// GameServer 状态
+const (
+	StateScheduled = "Scheduled"
+	StateReady     = "Ready"
+	StateAllocated = "Allocated"
+	StateReserved  = "Reserved"
+	StateShutdown  = "Shutdown"
+)
+
+// GameServer is the part of the Agones GameServer resource the wrapper uses.
+type GameServer struct {
+	ObjectMeta ObjectMeta `json:"object_meta"`
+	Status     Status     `json:"status"`
+}
+
+type ObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Uid         string            `json:"uid"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type Status struct {
+	State   string `json:"state"`
+	Address string `json:"address"`
+	Ports   []Port `json:"ports,omitempty"`
+}
+
+type Port struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+// Client calls one SDK sidecar.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewClientFromEnv returns a client for the sidecar of this pod, on
+// AGONES_SDK_HTTP_PORT or the default port.
+func NewClientFromEnv() *Client {
+	port := os.Getenv(PortEnv)
+	if port == "" {
+		port = DefaultPort
+	}
+	return NewClient("http://localhost:" + port)
+}
+
+func (c *Client) Ready(ctx context.Context) error {
+	return c.post(ctx, "/ready", struct{}{}, nil)
+}
+
+// Health sends one health ping; Agones marks the game server Unhealthy when
+// pings stop for longer than its health period.
+func (c *Client) Health(ctx context.Context) error {
+	return c.post(ctx, "/health", struct{}{}, nil)
+}
+
+func (c *Client) Allocate(ctx context.Context) error {
+	return c.post(ctx, "/allocate", struct{}{}, nil)
+}
+
+func (c *Client) Shutdown(ctx context.Context) error {
+	return c.post(ctx, "/shutdown", struct{}{}, nil)
+}
+
+// PlayerConnect and PlayerDisconnect need the PlayerTracking feature gate.
+func (c *Client) PlayerConnect(ctx context.Context, playerId string) error {
+	return c.post(ctx, "/alpha/player/connect", map[string]string{"playerID": playerId}, nil)
+}
+
+func (c *Client) PlayerDisconnect(ctx context.Context, playerId string) error {
+	return c.post(ctx, "/alpha/player/disconnect", map[string]string{"playerID": playerId}, nil)
+}
+
+func (c *Client) GameServer(ctx context.Context) (*GameServer, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/gameserver", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	gs := &GameServer{}
+	if err := c.do(c.HTTPClient, req, gs); err != nil {
+		return nil, err
+	}
+	return gs, nil
+}
+
+// WatchGameServer calls fn with the GameServer on every change until ctx is
+// done or the stream breaks.
+func (c *Client) WatchGameServer(ctx context.Context, fn func(*GameServer)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/watch/gameserver", nil)
+	if err != nil {
+		return err
+	}
+
+	// 长连接，不能用带超时的 client
+	resp, err := (&http.Client{Transport: c.HTTPClient.Transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var msg struct {
+			Result *GameServer `json:"result"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil || msg.Result == nil {
+			continue
+		}
+		fn(msg.Result)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(c.HTTPClient, req, out)
+}
+
+func (c *Client) do(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func statusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("agones sdk %s %s: %s: %s", resp.Request.Method, resp.Request.URL.Path, resp.Status,
+		bytes.TrimSpace(body))
+}
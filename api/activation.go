@@ -0,0 +1,97 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"os/exec"
+	"strings"
+	"supertuxkart/gsemanager"
+	"sync"
+	"time"
+)
+
+// ActivationCheck can veto a game server session before it is activated; a
+// non-nil error is returned to the agent instead of activating.
+type ActivationCheck func(ctx context.Context, gameServerSession *gsemanager.GameServerSession) error
+
+var (
+	activationMu sync.RWMutex
+	// activationCheck 为空时不检查
+	activationCheck ActivationCheck
+	// activationReadyTimeout 激活前等待游戏开始监听的时间，0 表示不等待
+	activationReadyTimeout time.Duration
+)
+
+// SetActivationCheck installs check for every OnStartGameServerSession.
+func SetActivationCheck(check ActivationCheck) {
+	activationMu.Lock()
+	defer activationMu.Unlock()
+
+	activationCheck = check
+}
+
+// SetActivationReadyTimeout makes OnStartGameServerSession wait up to timeout
+// for the game to listen before activating, and fail with Unavailable after.
+func SetActivationReadyTimeout(timeout time.Duration) {
+	activationMu.Lock()
+	defer activationMu.Unlock()
+
+	activationReadyTimeout = timeout
+}
+
+func activationSettings() (ActivationCheck, time.Duration) {
+	activationMu.RLock()
+	defer activationMu.RUnlock()
+
+	return activationCheck, activationReadyTimeout
+}
+
+// ScriptActivationCheck runs path with the session as JSON on stdin and
+// GSE_GAME_SERVER_SESSION_ID set; a non-zero exit vetoes the session with
+// the script's output as the message.
+func ScriptActivationCheck(path string, timeout time.Duration) ActivationCheck {
+	return func(ctx context.Context, gameServerSession *gsemanager.GameServerSession) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		body, _ := json.Marshal(gameServerSession)
+		cmd := exec.CommandContext(ctx, path) // #nosec
+		cmd.Stdin = bytes.NewReader(body)
+		cmd.Env = append(os.Environ(), "GSE_GAME_SERVER_SESSION_ID="+gameServerSession.GameServerSessionId)
+
+		out, err := cmd.CombinedOutput()
+		if ctx.Err() == context.DeadlineExceeded {
+			return status.Errorf(codes.DeadlineExceeded, "activation check timed out after %s", timeout)
+		}
+		if err != nil {
+			msg := strings.TrimSpace(string(out))
+			if msg == "" {
+				msg = err.Error()
+			}
+			return status.Errorf(codes.FailedPrecondition, "activation vetoed: %s", msg)
+		}
+		return nil
+	}
+}
+
+// validateGameServerSession rejects start requests the agent should never send.
+func validateGameServerSession(gameServerSession *gsemanager.GameServerSession) error {
+	if gameServerSession == nil {
+		return status.Error(codes.InvalidArgument, "gameServerSession is required")
+	}
+	if strings.TrimSpace(gameServerSession.GameServerSessionId) == "" {
+		return status.Error(codes.InvalidArgument, "gameServerSession.gameServerSessionId is required")
+	}
+	if gameServerSession.MaxPlayers < 0 {
+		return status.Errorf(codes.InvalidArgument, "gameServerSession.maxPlayers must not be negative, got %d",
+			gameServerSession.MaxPlayers)
+	}
+	if gameServerSession.Port < 0 || gameServerSession.Port > 65535 {
+		return status.Errorf(codes.InvalidArgument, "gameServerSession.port %d out of range", gameServerSession.Port)
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"supertuxkart/logger"
+	"sync"
+)
+
+// 接口权限，每个路由需要一个 scope
+const (
+	ScopeRead      = "read"      // 查询类接口
+	ScopePlayer    = "player"    // 玩家会话、健康状态、自定义数据
+	ScopeLifecycle = "lifecycle" // 结束游戏会话、结束进程
+)
+
+var allScopes = []string{ScopeRead, ScopePlayer, ScopeLifecycle}
+
+// endpointScopes 列出每个路由需要的 scope，未列出的路由需要 ScopeRead；探针接口不检查 token
+var endpointScopes = map[string]string{
+	"/gse/login":                         ScopePlayer,
+	"/gse/logout":                        ScopePlayer,
+	"/gse/terminate-game-server-session": ScopeLifecycle,
+	"/gse/end-process":                   ScopeLifecycle,
+	"/gse/describe-player-sessions":      ScopeRead,
+	"/gse/update-player-session-policy":  ScopePlayer,
+	"/gse/report-custom-data":            ScopePlayer,
+	"/gse/set-process-health-status":     ScopePlayer,
+	"/gse/events":                        ScopeRead,
+	"/metrics":                           ScopeRead,
+
+	"/gse/standalone/start-game-server-session": ScopeLifecycle,
+	"/gse/standalone/process-terminate":         ScopeLifecycle,
+
+	"/v1/player-sessions":                ScopeRead,
+	"/v1/player-sessions/cached":         ScopeRead,
+	"/v1/player-sessions/all":            ScopeRead,
+	"/v1/player-sessions/accept":         ScopePlayer,
+	"/v1/player-sessions/remove":         ScopePlayer,
+	"/v1/player-sessions/batch-accept":   ScopePlayer,
+	"/v1/player-sessions/batch-remove":   ScopePlayer,
+	"/v1/player-session-creation-policy": ScopePlayer,
+	"/v1/game-server-session":            ScopeRead,
+	"/v1/status":                         ScopeRead,
+	"/v1/game-server-session/terminate":  ScopeLifecycle,
+	"/v1/process/end":                    ScopeLifecycle,
+	"/v1/custom-data":                    ScopePlayer,
+	"/v1/custom-data/push":               ScopePlayer,
+	"/v1/health-status":                  ScopePlayer,
+}
+
+// localServicePrefix 只有 LocalGseService 检查 token，agent 的回调和 grpc.health.v1 不检查
+const localServicePrefix = "/localService.LocalGseService/"
+
+// methodScopes 列出 LocalGseService 每个方法需要的 scope，与对应的 /v1 路由一致；未列出的需要 ScopeRead
+var methodScopes = map[string]string{
+	localServicePrefix + "Login":                             ScopePlayer,
+	localServicePrefix + "Logout":                            ScopePlayer,
+	localServicePrefix + "BatchLogin":                        ScopePlayer,
+	localServicePrefix + "BatchLogout":                       ScopePlayer,
+	localServicePrefix + "DescribePlayerSessions":            ScopeRead,
+	localServicePrefix + "QueryCachedPlayerSessions":         ScopeRead,
+	localServicePrefix + "GetGameServerSession":              ScopeRead,
+	localServicePrefix + "GetStatus":                         ScopeRead,
+	localServicePrefix + "UpdatePlayerSessionCreationPolicy": ScopePlayer,
+	localServicePrefix + "TerminateGameServerSession":        ScopeLifecycle,
+	localServicePrefix + "ProcessEnding":                     ScopeLifecycle,
+	localServicePrefix + "ReportCustomData":                  ScopePlayer,
+	localServicePrefix + "PushCustomData":                    ScopePlayer,
+	localServicePrefix + "SetHealthStatus":                   ScopePlayer,
+}
+
+var (
+	grpcAuthMu sync.RWMutex
+	// grpcAuth 是 http server 生成的 token，grpc server 启动时用它检查 LocalGseService
+	grpcAuth *httpAuth
+)
+
+func setGrpcAuth(a *httpAuth) {
+	grpcAuthMu.Lock()
+	defer grpcAuthMu.Unlock()
+
+	grpcAuth = a
+}
+
+func getGrpcAuth() *httpAuth {
+	grpcAuthMu.RLock()
+	defer grpcAuthMu.RUnlock()
+
+	return grpcAuth
+}
+
+// httpAuth checks the bearer token of every request against the tokens
+// generated at startup: token carries the configured scopes, readToken only
+// ScopeRead. The grpc servers check LocalGseService calls with the same
+// tokens.
+type httpAuth struct {
+	token       string
+	readToken   string
+	tokenScopes map[string]bool
+
+	mu         sync.Mutex
+	rejections map[string]int64
+}
+
+func newHttpAuth(scopes []string) (*httpAuth, error) {
+	a := &httpAuth{
+		tokenScopes: make(map[string]bool),
+		rejections:  make(map[string]int64),
+	}
+
+	for _, scope := range scopes {
+		if !isScope(scope) {
+			return nil, fmt.Errorf("unknown http scope %q, expect one of %v", scope, allScopes)
+		}
+		a.tokenScopes[scope] = true
+	}
+
+	var err error
+	if a.token, err = newToken(); err != nil {
+		return nil, err
+	}
+	if a.readToken, err = newToken(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func isScope(scope string) bool {
+	for _, s := range allScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func requiredScope(path string) string {
+	if scope, ok := endpointScopes[path]; ok {
+		return scope
+	}
+	return ScopeRead
+}
+
+func requiredMethodScope(method string) string {
+	if scope, ok := methodScopes[method]; ok {
+		return scope
+	}
+	return ScopeRead
+}
+
+// scopesFor returns the scopes granted to the bearer token in an
+// Authorization header, or nil if the token is missing or unknown.
+func (a *httpAuth) scopesFor(header string) map[string]bool {
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil
+	}
+	presented := []byte(strings.TrimPrefix(header, "Bearer "))
+
+	if subtle.ConstantTimeCompare(presented, []byte(a.token)) == 1 {
+		return a.tokenScopes
+	}
+	if subtle.ConstantTimeCompare(presented, []byte(a.readToken)) == 1 {
+		return map[string]bool{ScopeRead: true}
+	}
+	return nil
+}
+
+// countRejection records one more rejection for reason and returns the total.
+func (a *httpAuth) countRejection(reason string) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.rejections[reason]++
+	return a.rejections[reason]
+}
+
+func (a *httpAuth) reject(w http.ResponseWriter, req *http.Request, reason string, err error) {
+	count := a.countRejection(reason)
+	logger.Warn("http request rejected", zap.String("reason", reason), zap.String("method", req.Method),
+		zap.String("path", req.URL.Path), zap.String("remote", req.RemoteAddr), zap.Int64("count", count))
+	writeError(w, err)
+}
+
+// Rejections returns how many http requests and grpc calls were rejected,
+// by reason.
+func (a *httpAuth) Rejections() map[string]int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make(map[string]int64, len(a.rejections))
+	for reason, count := range a.rejections {
+		result[reason] = count
+	}
+	return result
+}
+
+func (a *httpAuth) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if probeEndpoints[req.URL.Path] {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		scopes := a.scopesFor(req.Header.Get("Authorization"))
+		if scopes == nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			a.reject(w, req, "unauthenticated", status.Error(codes.Unauthenticated, "missing or invalid bearer token"))
+			return
+		}
+
+		scope := requiredScope(req.URL.Path)
+		if !scopes[scope] {
+			a.reject(w, req, "forbidden", status.Errorf(codes.PermissionDenied, "token lacks scope %q", scope))
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// unaryInterceptor checks the bearer token sent as authorization metadata on
+// LocalGseService calls, and the scope of the method.
+func (a *httpAuth) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.checkGrpc(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor applies the same check to streaming calls.
+func (a *httpAuth) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := a.checkGrpc(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (a *httpAuth) checkGrpc(ctx context.Context, method string) error {
+	if !strings.HasPrefix(method, localServicePrefix) {
+		return nil
+	}
+
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+
+	scopes := a.scopesFor(header)
+	if scopes == nil {
+		return a.rejectGrpc(method, "unauthenticated", status.Error(codes.Unauthenticated, "missing or invalid bearer token"))
+	}
+	if scope := requiredMethodScope(method); !scopes[scope] {
+		return a.rejectGrpc(method, "forbidden", status.Errorf(codes.PermissionDenied, "token lacks scope %q", scope))
+	}
+	return nil
+}
+
+func (a *httpAuth) rejectGrpc(method, reason string, err error) error {
+	count := a.countRejection(reason)
+	logger.Warn("grpc call rejected", zap.String("reason", reason), zap.String("method", method),
+		zap.Int64("count", count))
+	return err
+}
+
+// writePrometheus writes Rejections as gse_auth_rejections_total.
+func (a *httpAuth) writePrometheus(w http.ResponseWriter) {
+	rejections := a.Rejections()
+	reasons := make([]string, 0, len(rejections))
+	for reason := range rejections {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	fmt.Fprintln(w, "# HELP gse_auth_rejections_total HTTP requests and gRPC calls rejected by token auth, by reason.")
+	fmt.Fprintln(w, "# TYPE gse_auth_rejections_total counter")
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "gse_auth_rejections_total{reason=%s} %d\n", strconv.Quote(reason), rejections[reason])
+	}
+}
+
+// writeTokenFile stores both tokens as JSON, readable only by the owner.
+func (a *httpAuth) writeTokenFile(path string) error {
+	b, err := json.Marshal(map[string]string{
+		"token":     a.token,
+		"readToken": a.readToken,
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
+	"supertuxkart/events"
+	"time"
+)
+
+// sseKeepAlive 空闲时发送注释行，防止连接被中间代理断开
+const sseKeepAlive = 15 * time.Second
+
+// Events streams wrapper events as Server-Sent Events. A new stream starts
+// with the last ?replay= kept events (all kept events by default), or with
+// those after Last-Event-ID on reconnect. ?types= limits the stream to a
+// comma separated list of event types. The server closes the stream when
+// the client falls behind; reconnecting with Last-Event-ID resumes it.
+func (h *httpProcess) Events(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, status.Error(codes.Unimplemented, "streaming not supported"))
+		return
+	}
+
+	query := req.URL.Query()
+	types, err := events.ParseTypes(query.Get("types"))
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	replay := -1
+	if s := query.Get("replay"); s != "" {
+		if replay, err = strconv.Atoi(s); err != nil || replay < 0 {
+			writeError(w, status.Error(codes.InvalidArgument, "replay must be a non-negative integer"))
+			return
+		}
+	}
+
+	var lastEventId uint64
+	if s := req.Header.Get("Last-Event-ID"); s != "" {
+		if lastEventId, err = strconv.ParseUint(s, 10, 64); err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, "Last-Event-ID must be an event seq"))
+			return
+		}
+	}
+
+	past, ch, cancel := events.Replay("sse "+req.RemoteAddr, lastEventId, replay)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, ev := range past {
+		if events.Matches(ev.Type, types) {
+			writeEvent(w, ev)
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			if events.Matches(ev.Type, types) {
+				writeEvent(w, ev)
+				flusher.Flush()
+			}
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, ev events.Event) {
+	data, _ := json.Marshal(ev)
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.Seq, ev.Type, data)
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"net/http"
+	"strconv"
+	"strings"
+	"supertuxkart/gsemanager"
+	"supertuxkart/localsdk"
+	"supertuxkart/logger"
+)
+
+// /v1 接口由 grpc-gateway 根据 localsdk/local_grpc_service.proto 生成，直接调用 localService，
+// HTTP 状态码由 gRPC status 推导，出错时统一返回 errorResponse
+
+// streamPlayerSessionsPath 是唯一不由 proto 里的 google.api.http 生成的 /v1 路由
+const streamPlayerSessionsPath = "/v1/player-sessions/all"
+
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type errorResponse struct {
+	Error errorDetail `json:"error"`
+}
+
+func (h *httpProcess) registerApiV1() {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: false},
+		}),
+		runtime.WithErrorHandler(gatewayErrorHandler),
+		runtime.WithRoutingErrorHandler(gatewayRoutingErrorHandler),
+	)
+
+	err := localsdk.RegisterLocalGseServiceHandlerServer(context.Background(), mux, GetLocalService())
+	if err != nil {
+		logger.Fatal("register grpc gateway fail", zap.Error(err))
+	}
+
+	// 流式接口 grpc-gateway 不支持进程内调用，单独注册
+	err = mux.HandlePath(http.MethodGet, streamPlayerSessionsPath, h.StreamPlayerSessions)
+	if err != nil {
+		logger.Fatal("register stream handler fail", zap.Error(err))
+	}
+
+	http.Handle("/v1/", mux)
+}
+
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler,
+	w http.ResponseWriter, req *http.Request, err error) {
+	writeError(w, err)
+}
+
+func gatewayRoutingErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler,
+	w http.ResponseWriter, req *http.Request, httpStatus int) {
+	switch httpStatus {
+	case http.StatusMethodNotAllowed:
+		writeJSON(w, httpStatus, &errorResponse{
+			Error: errorDetail{Code: "MethodNotAllowed", Message: "method " + req.Method + " not allowed"},
+		})
+	case http.StatusNotFound:
+		writeError(w, status.Error(codes.NotFound, "no route for "+req.URL.Path))
+	default:
+		writeError(w, status.Error(codes.InvalidArgument, http.StatusText(httpStatus)))
+	}
+}
+
+// StreamPlayerSessions walks every DescribePlayerSessions page and writes the
+// player sessions as newline-delimited JSON, flushing after each page. An
+// error after the first page is reported as a final errorResponse line.
+func (h *httpProcess) StreamPlayerSessions(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	query := req.URL.Query()
+
+	statusFilter, err := gsemanager.ParsePlayerSessionStatus(query.Get("playerSessionStatusFilter"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var pageSize int
+	if limitStr := query.Get("limit"); limitStr != "" {
+		pageSize, err = strconv.Atoi(limitStr)
+		if err != nil || pageSize < 0 {
+			writeError(w, status.Errorf(codes.InvalidArgument, "limit must be a non-negative integer, got %q", limitStr))
+			return
+		}
+	}
+
+	gseManager, err := gseManagerFor(req.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	pager := gseManager.NewPlayerSessionPager(gsemanager.DescribePlayerSessionsQuery{
+		GameServerSessionId: query.Get("gameServerSessionId"),
+		PlayerId:            query.Get("playerId"),
+		PlayerSessionId:     query.Get("playerSessionId"),
+		StatusFilter:        statusFilter,
+		PageSize:            int32(pageSize),
+	})
+
+	marshaler := protojson.MarshalOptions{EmitUnpopulated: true}
+	flusher, _ := w.(http.Flusher)
+	started := false
+
+	for pager.HasNext() {
+		page, err := pager.Next(req.Context())
+		if err != nil {
+			if !started {
+				writeError(w, err)
+				return
+			}
+			st := status.Convert(err)
+			json.NewEncoder(w).Encode(&errorResponse{
+				Error: errorDetail{Code: st.Code().String(), Message: st.Message()},
+			})
+			return
+		}
+
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		for _, ps := range page {
+			line, err := marshaler.Marshal(toLocalPlayerSession(ps))
+			if err != nil {
+				logger.Error("marshal player session fail", zap.Error(err))
+				continue
+			}
+			w.Write(append(line, '\n'))
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	if !started {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// allowMethods rejects requests whose method is not listed with 405 and an Allow header.
+func allowMethods(handler http.HandlerFunc, methods ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		for _, m := range methods {
+			if req.Method == m {
+				handler(w, req)
+				return
+			}
+		}
+
+		w.Header().Set("Allow", strings.Join(methods, ", "))
+		writeJSON(w, http.StatusMethodNotAllowed, &errorResponse{
+			Error: errorDetail{Code: "MethodNotAllowed", Message: "method " + req.Method + " not allowed"},
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, httpStatus int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("write http response fail", zap.Error(err))
+	}
+}
+
+// writeError writes err in the errorResponse envelope, with the HTTP status
+// grpc-gateway derives from its gRPC code.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeJSON(w, runtime.HTTPStatusFromCode(st.Code()), &errorResponse{
+		Error: errorDetail{Code: st.Code().String(), Message: st.Message()},
+	})
+}
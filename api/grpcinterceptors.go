@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"supertuxkart/logger"
+	"sync"
+	"time"
+)
+
+// recoveryInterceptor 把 handler 里的 panic 变成 Internal 错误，不让 agent 的回调拖垮整个 wrapper
+func recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("grpc handler panic", zap.String("method", info.FullMethod),
+				zap.Any("panic", r), zap.ByteString("stack", debug.Stack()))
+			err = status.Errorf(codes.Internal, "panic in %s: %v", info.FullMethod, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// streamRecoveryInterceptor 对流式调用（比如 health Watch）做同样的 panic 恢复
+func streamRecoveryInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("grpc handler panic", zap.String("method", info.FullMethod),
+				zap.Any("panic", r), zap.ByteString("stack", debug.Stack()))
+			err = status.Errorf(codes.Internal, "panic in %s: %v", info.FullMethod, r)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+// accessLogInterceptor logs every call with its code and latency, and
+// records them in grpcMetrics.
+func (s *rpcService) accessLogInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	elapsed := time.Since(start)
+
+	code := status.Code(err)
+	grpcMetrics.observe(s.grpcPort, info.FullMethod, code, elapsed)
+
+	fields := []zap.Field{
+		zap.String("method", info.FullMethod),
+		zap.Int("grpcPort", s.grpcPort),
+		zap.String("code", code.String()),
+		zap.Duration("elapsed", elapsed),
+	}
+	if err != nil {
+		logger.Info("grpc access", append(fields, zap.Error(err))...)
+	} else {
+		logger.Info("grpc access", fields...)
+	}
+	return resp, err
+}
+
+// streamAccessLogInterceptor logs and records streaming calls when they
+// end, the same way accessLogInterceptor does for unary ones.
+func (s *rpcService) streamAccessLogInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	elapsed := time.Since(start)
+
+	code := status.Code(err)
+	grpcMetrics.observe(s.grpcPort, info.FullMethod, code, elapsed)
+
+	fields := []zap.Field{
+		zap.String("method", info.FullMethod),
+		zap.Int("grpcPort", s.grpcPort),
+		zap.String("code", code.String()),
+		zap.Duration("elapsed", elapsed),
+	}
+	if err != nil {
+		logger.Info("grpc access", append(fields, zap.Error(err))...)
+	} else {
+		logger.Info("grpc access", fields...)
+	}
+	return err
+}
+
+type grpcMetricKey struct {
+	grpcPort int
+	method   string
+	code     codes.Code
+}
+
+type grpcMetricValue struct {
+	count   int64
+	seconds float64
+}
+
+// grpcServerMetrics 按端口、方法和返回码统计 grpc 调用次数和耗时
+type grpcServerMetrics struct {
+	mu     sync.Mutex
+	values map[grpcMetricKey]*grpcMetricValue
+}
+
+var grpcMetrics = &grpcServerMetrics{values: make(map[grpcMetricKey]*grpcMetricValue)}
+
+func (m *grpcServerMetrics) observe(grpcPort int, method string, code codes.Code, elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := grpcMetricKey{grpcPort: grpcPort, method: method, code: code}
+	v, ok := m.values[key]
+	if !ok {
+		v = &grpcMetricValue{}
+		m.values[key] = v
+	}
+	v.count++
+	v.seconds += elapsed.Seconds()
+}
+
+// writePrometheus writes the counters in the Prometheus text format, with
+// the metric names go-grpc-prometheus uses.
+func (m *grpcServerMetrics) writePrometheus(w http.ResponseWriter) {
+	m.mu.Lock()
+	keys := make([]grpcMetricKey, 0, len(m.values))
+	values := make(map[grpcMetricKey]grpcMetricValue, len(m.values))
+	for key, v := range m.values {
+		keys = append(keys, key)
+		values[key] = *v
+	}
+	m.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].grpcPort != keys[j].grpcPort {
+			return keys[i].grpcPort < keys[j].grpcPort
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	labels := func(key grpcMetricKey) string {
+		return fmt.Sprintf(`grpc_port="%d",grpc_method=%s,grpc_code="%s"`,
+			key.grpcPort, strconv.Quote(key.method), key.code)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# HELP grpc_server_handled_total gRPC calls completed, by code.")
+	fmt.Fprintln(w, "# TYPE grpc_server_handled_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "grpc_server_handled_total{%s} %d\n", labels(key), values[key].count)
+	}
+	fmt.Fprintln(w, "# HELP grpc_server_handling_seconds_sum Time spent handling gRPC calls, by code.")
+	fmt.Fprintln(w, "# TYPE grpc_server_handling_seconds_sum counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "grpc_server_handling_seconds_sum{%s} %g\n", labels(key), values[key].seconds)
+	}
+}
+
+// Metrics serves the grpc server counters, and the auth rejections when
+// token auth is on, for Prometheus.
+func (h *httpProcess) Metrics(w http.ResponseWriter, req *http.Request) {
+	grpcMetrics.writePrometheus(w)
+	if h.auth != nil {
+		h.auth.writePrometheus(w)
+	}
+}
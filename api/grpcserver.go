@@ -2,28 +2,65 @@ package api
 
 import (
 	"context"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"net"
 	"strconv"
 	"strings"
+	"supertuxkart/events"
 	"supertuxkart/grpcsdk"
 	"supertuxkart/gsemanager"
+	"supertuxkart/localsdk"
 	"supertuxkart/logger"
+	"supertuxkart/tracing"
 	"sync"
+	"time"
 )
 
+// healthServices 是 grpc.health.v1 可以查询的服务名，"" 表示整体
+var healthServices = []string{
+	"",
+	"processService.GameServerGrpcSdkService",
+	"localService.LocalGseService",
+}
+
 var (
 	rpcServerIns *rpcService
 	once         sync.Once
+
+	// grpcReflection 打开后可以用 grpcurl 调试
+	grpcReflection   bool
+	grpcReflectionMu sync.RWMutex
+
+	terminationMu   sync.RWMutex
+	terminationOpts = gsemanager.TerminationOptions{Grace: 30 * time.Second, EndReserve: 10 * time.Second}
+	grpcStopTimeout = 5 * time.Second
+
+	localServiceIns  *localService
+	localServiceOnce sync.Once
 )
 
 type rpcService struct {
+	grpcPort int
+	server   *grpc.Server
+
+	// healthMu 保护 healthStatus 和 health，HTTP、grpc 和 Agones 的 ping 会并发读写
+	healthMu     sync.RWMutex
 	healthStatus bool
-	grpcPort     int
+	health       *health.Server
+	// startMu 串行处理 OnStartGameServerSession，重复请求在前一个完成后再判断
+	startMu sync.Mutex
+	// stopOnce 保证终止流程结束、信号和 main 退出时只停止一次
+	stopOnce sync.Once
 }
 
-//func GetRpcService() grpcsdk.GameServerGrpcSdkServiceServer {
+// func GetRpcService() grpcsdk.GameServerGrpcSdkServiceServer {
 func GetRpcService() *rpcService {
 	once.Do(func() {
 		rpcServerIns = new(rpcService)
@@ -33,6 +70,67 @@ func GetRpcService() *rpcService {
 	return rpcServerIns
 }
 
+// SetGrpcReflection registers server reflection on the grpc servers started
+// after it.
+func SetGrpcReflection(enabled bool) {
+	grpcReflectionMu.Lock()
+	defer grpcReflectionMu.Unlock()
+
+	grpcReflection = enabled
+}
+
+func grpcReflectionEnabled() bool {
+	grpcReflectionMu.RLock()
+	defer grpcReflectionMu.RUnlock()
+
+	return grpcReflection
+}
+
+// SetTerminationOptions tunes the workflow OnProcessTerminate starts.
+func SetTerminationOptions(opts gsemanager.TerminationOptions) {
+	terminationMu.Lock()
+	defer terminationMu.Unlock()
+
+	terminationOpts = opts
+}
+
+func terminationOptions() gsemanager.TerminationOptions {
+	terminationMu.RLock()
+	defer terminationMu.RUnlock()
+
+	return terminationOpts
+}
+
+// SetGrpcStopTimeout sets the time in-flight calls get when a process's grpc
+// server stops after its termination workflow.
+func SetGrpcStopTimeout(timeout time.Duration) {
+	terminationMu.Lock()
+	defer terminationMu.Unlock()
+
+	grpcStopTimeout = timeout
+}
+
+func grpcStopTimeoutOf() time.Duration {
+	terminationMu.RLock()
+	defer terminationMu.RUnlock()
+
+	return grpcStopTimeout
+}
+
+// NewRpcService returns the callback service of one more game server
+// process in multi-process mode; the first process uses GetRpcService.
+func NewRpcService() *rpcService {
+	return &rpcService{healthStatus: true}
+}
+
+func GetLocalService() *localService {
+	localServiceOnce.Do(func() {
+		localServiceIns = new(localService)
+	})
+
+	return localServiceIns
+}
+
 func (s *rpcService) StartGrpcServer() {
 	listen, err := net.Listen("tcp", "127.0.0.1:")
 	if err != nil {
@@ -48,50 +146,236 @@ func (s *rpcService) StartGrpcServer() {
 
 	logger.Info("grpc listen port is", zap.Int("port", s.grpcPort))
 
-	grpcServer := grpc.NewServer()
+	// recovery 放在最里层，panic 也会被记录到访问日志和统计里；被拒绝的调用同样记录
+	interceptors := []grpc.UnaryServerInterceptor{s.accessLogInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{s.streamAccessLogInterceptor}
+	if auth := getGrpcAuth(); auth != nil {
+		interceptors = append(interceptors, auth.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, auth.streamInterceptor)
+	}
+	interceptors = append(interceptors, recoveryInterceptor)
+	streamInterceptors = append(streamInterceptors, streamRecoveryInterceptor)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...))
 	grpcsdk.RegisterGameServerGrpcSdkServiceServer(grpcServer, s)
+	localsdk.RegisterLocalGseServiceServer(grpcServer, &localService{grpcPort: s.grpcPort})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	s.healthMu.Lock()
+	s.health = healthServer
+	s.updateServingStatus()
+	s.healthMu.Unlock()
+	if grpcReflectionEnabled() {
+		reflection.Register(grpcServer)
+	}
+	s.server = grpcServer
+	registerRpcService(s)
 	logger.Info("start grpc server success")
-	go grpcServer.Serve(listen)
+	go func() {
+		if err := grpcServer.Serve(listen); err != nil {
+			logger.Error("grpc server stopped", zap.Int("port", s.grpcPort), zap.Error(err))
+		}
+	}()
+}
+
+// StopGrpcServer lets in-flight calls finish, up to timeout, then closes
+// the remaining connections.
+func (s *rpcService) StopGrpcServer(timeout time.Duration) {
+	if s.server == nil {
+		return
+	}
+	s.stopOnce.Do(func() { s.stopGrpcServer(timeout) })
+}
+
+func (s *rpcService) stopGrpcServer(timeout time.Duration) {
+	s.healthMu.RLock()
+	s.health.Shutdown()
+	s.healthMu.RUnlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logger.Info("grpc server stopped", zap.Int("port", s.grpcPort))
+	case <-time.After(timeout):
+		logger.Info("grpc graceful stop timed out", zap.Int("port", s.grpcPort), zap.Duration("timeout", timeout))
+		s.server.Stop()
+	}
+}
+
+// gseManager returns the manager of the process that announced this
+// service's port in ProcessReady.
+func (s *rpcService) gseManager() (*gsemanager.GseManager, error) {
+	return gsemanager.GetGseManagerByGrpcPort(s.grpcPort)
 }
 
 func (s *rpcService) GetGrpcPort() int {
 	return s.grpcPort
 }
 
+func (s *rpcService) HealthStatus() bool {
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+
+	return s.healthStatus
+}
+
 func (s *rpcService) SetHealthStatus(healthStatus bool) {
+	s.healthMu.Lock()
+	changed := s.healthStatus != healthStatus
 	s.healthStatus = healthStatus
+	s.updateServingStatus()
+	s.healthMu.Unlock()
+
+	if changed {
+		events.Publish(events.Event{Type: events.HealthChanged, GrpcPort: s.grpcPort, Healthy: &healthStatus})
+	}
+}
+
+// updateServingStatus mirrors the health status into grpc.health.v1, both
+// overall ("") and for each service. The caller holds healthMu.
+func (s *rpcService) updateServingStatus() {
+	if s.health == nil {
+		return
+	}
+
+	servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+	if s.healthStatus {
+		servingStatus = healthpb.HealthCheckResponse_SERVING
+	}
+	for _, service := range healthServices {
+		s.health.SetServingStatus(service, servingStatus)
+	}
 }
 
 func (s *rpcService) OnHealthCheck(ctx context.Context, req *grpcsdk.HealthCheckRequest) (*grpcsdk.HealthCheckResponse, error) {
+	healthStatus := s.HealthStatus()
 	resp := &grpcsdk.HealthCheckResponse{
-		HealthStatus: s.healthStatus,
+		HealthStatus: healthStatus,
 	}
 
-	logger.Info("OnHealthCheck status: " + strconv.FormatBool(s.healthStatus))
+	logger.Info("OnHealthCheck status: " + strconv.FormatBool(healthStatus))
 	return resp, nil
 }
 
+// OnStartGameServerSession starts the session the agent sent, see
+// startGameServerSession.
 func (s *rpcService) OnStartGameServerSession(ctx context.Context, req *grpcsdk.StartGameServerSessionRequest) (*grpcsdk.ProcessResponse, error) {
-	gseManager := gsemanager.GetGseManager()
-	gseManager.SetGameServerSession(req.GameServerSession)
-	gseManager.ActivateGameServerSession(req.GameServerSession.GameServerSessionId, req.GameServerSession.MaxPlayers)
+	err := s.startGameServerSession(ctx, gsemanager.GameServerSessionFromGse(req.GetGameServerSession()))
+	if err != nil {
+		return nil, err
+	}
+	return new(grpcsdk.ProcessResponse), nil
+}
+
+// startGameServerSession validates the session, waits for the game to
+// listen, runs the activation check and activates. A repeated request for
+// the session already activated succeeds without activating again; any
+// failure is returned as a gRPC status.
+func (s *rpcService) startGameServerSession(ctx context.Context, gameServerSession *gsemanager.GameServerSession) error {
+	if err := validateGameServerSession(gameServerSession); err != nil {
+		logger.Warn("OnStartGameServerSession rejected", zap.Error(err))
+		return err
+	}
+	gameServerSessionId := gameServerSession.GameServerSessionId
 
-	resp := new(grpcsdk.ProcessResponse)
+	s.startMu.Lock()
+	defer s.startMu.Unlock()
 
-	return resp, nil
+	gseManager, err := s.gseManager()
+	if err != nil {
+		return err
+	}
+	// 只有当前会话还没被 TerminateGameServerSession 结束时才拒绝新会话
+	state, current, live := gseManager.State(), gseManager.GameServerSessionId(), gseManager.SessionLive()
+	switch {
+	case state.AtLeast(gsemanager.StateTerminating):
+		return status.Errorf(codes.FailedPrecondition, "process is %s", state)
+	case live && current == gameServerSessionId:
+		logger.Info("duplicate OnStartGameServerSession ignored", zap.String("gameServerSessionId", gameServerSessionId))
+		return nil
+	case live:
+		return status.Errorf(codes.FailedPrecondition, "process already hosts game server session %s", current)
+	case current == gameServerSessionId:
+		return status.Errorf(codes.FailedPrecondition, "game server session %s already ended", current)
+	}
+
+	gseManager.Trace().Start(gameServerSessionId)
+	_, span := tracing.Start(gseManager.Trace().Context(), "OnStartGameServerSession",
+		attribute.String("gameServerSessionId", gameServerSessionId),
+		attribute.Int("maxPlayers", int(gameServerSession.MaxPlayers)))
+
+	events.Publish(events.Event{Type: events.SessionStart, GrpcPort: s.grpcPort, GameServerSessionId: gameServerSessionId})
+
+	err = s.activate(ctx, gseManager, gameServerSession)
+	tracing.End(span, err)
+	if err != nil {
+		logger.Warn("OnStartGameServerSession fail", zap.String("gameServerSessionId", gameServerSessionId), zap.Error(err))
+		return status.Convert(err).Err()
+	}
+
+	events.Publish(events.Event{Type: events.SessionActivated, GrpcPort: s.grpcPort,
+		GameServerSessionId: gameServerSessionId})
+	return nil
+}
+
+func (s *rpcService) activate(ctx context.Context, gseManager *gsemanager.GseManager,
+	gameServerSession *gsemanager.GameServerSession) error {
+	check, readyTimeout := activationSettings()
+
+	// 游戏还没开始监听时先等一会，超时则让 agent 重试
+	if readyTimeout > 0 {
+		waitCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+		err := gseManager.WaitForState(waitCtx, gsemanager.StateServerReady)
+		cancel()
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "game server is not listening yet: %s", status.Convert(err).Message())
+		}
+	}
+
+	if check != nil {
+		if err := check(ctx, gameServerSession); err != nil {
+			if _, ok := status.FromError(err); !ok {
+				err = status.Errorf(codes.FailedPrecondition, "activation vetoed: %v", err)
+			}
+			return err
+		}
+	}
+
+	return gseManager.StartGameServerSession(gameServerSession)
 }
 
+// OnProcessTerminate acknowledges at once and leaves the drain,
+// TerminateGameServerSession and ProcessEnding to a background workflow;
+// repeated notices only log. Progress shows in GetStatus.
 func (s *rpcService) OnProcessTerminate(ctx context.Context, req *grpcsdk.ProcessTerminateRequest) (*grpcsdk.ProcessResponse, error) {
 	logger.Info("OnProcessTerminate called, req:" + req.String())
+	if req.TerminationTime < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "terminationTime must not be negative, got %d", req.TerminationTime)
+	}
+	gseManager, err := s.gseManager()
+	if err != nil {
+		return nil, err
+	}
+	_, span := tracing.Start(gseManager.Trace().Context(), "OnProcessTerminate",
+		attribute.Int64("terminationTime", req.TerminationTime))
+	defer span.End()
 
-	gseManager := gsemanager.GetGseManager()
-	gseManager.SetTerminationTime(req.TerminationTime)
-	//结束游戏会话
-	gseManager.TerminateGameServerSession()
-
-	// 进程退出
-	gseManager.ProcessEnding()
+	// GSE 模式下日志不会结束，流程结束后就停止这个进程的 grpc server
+	opts := terminationOptions()
+	opts.Done = func(gsemanager.Termination) {
+		s.StopGrpcServer(grpcStopTimeoutOf())
+	}
+	if !gseManager.BeginTermination(req.TerminationTime, opts) {
+		logger.Info("duplicate OnProcessTerminate ignored",
+			zap.String("phase", string(gseManager.Termination().Phase)))
+		return new(grpcsdk.ProcessResponse), nil
+	}
 
-	resp := new(grpcsdk.ProcessResponse)
-	return resp, nil
+	events.Publish(events.Event{Type: events.Terminate, GrpcPort: s.grpcPort,
+		GameServerSessionId: gseManager.GameServerSessionId(), TerminationTime: req.TerminationTime})
+	return new(grpcsdk.ProcessResponse), nil
 }
@@ -20,18 +20,68 @@ const (
 )
 
 type httpProcess struct {
+	listenAddr   string
 	httpPort     int
 	HttpPortChan chan int
+	auth         *httpAuth
+	standalone   bool
+	// routes 记录 registerApi 注册的路由，openapi 测试用它核对 openapi.json
+	routes []route
 }
 
-func NewHttpProcess() *httpProcess {
+// route is one path registered on the default mux; no methods means any
+// method is accepted, as the legacy /gse routes do.
+type route struct {
+	pattern string
+	methods []string
+}
+
+// NewHttpProcess 创建 http server，listenAddr 为空时只监听本机回环地址
+func NewHttpProcess(listenAddr string) *httpProcess {
+	if listenAddr == "" {
+		listenAddr = "127.0.0.1:"
+	}
+
 	h := &httpProcess{
+		listenAddr:   listenAddr,
 		httpPort:     0,
 		HttpPortChan: make(chan int),
 	}
 	return h
 }
 
+// EnableTokenAuth requires a bearer token on every request, and on the
+// LocalGseService calls of grpc servers started afterwards. The generated
+// token is granted scopes, the read token only ScopeRead; both are written to
+// tokenFile when it is not empty and handed to the game via ChildEnv.
+func (h *httpProcess) EnableTokenAuth(scopes []string, tokenFile string) error {
+	auth, err := newHttpAuth(scopes)
+	if err != nil {
+		return err
+	}
+
+	if tokenFile != "" {
+		if err := auth.writeTokenFile(tokenFile); err != nil {
+			return err
+		}
+	}
+
+	h.auth = auth
+	setGrpcAuth(auth)
+	logger.Info("http token auth enabled", zap.Strings("scopes", scopes), zap.String("tokenFile", tokenFile))
+	return nil
+}
+
+// ChildEnv returns the environment variables telling the game process how to
+// reach this server.
+func (h *httpProcess) ChildEnv() []string {
+	env := []string{"GSE_HTTP_PORT=" + strconv.Itoa(h.httpPort)}
+	if h.auth != nil {
+		env = append(env, "GSE_HTTP_TOKEN="+h.auth.token, "GSE_HTTP_READ_TOKEN="+h.auth.readToken)
+	}
+	return env
+}
+
 type response struct {
 	Code    int32       `json:"code"`
 	Message string      `json:"message"`
@@ -39,19 +89,39 @@ type response struct {
 }
 
 func (h *httpProcess) registerApi() {
-	http.HandleFunc("/gse/login", h.Login)
-	http.HandleFunc("/gse/logout", h.LoginOut)
-	http.HandleFunc("/gse/terminate-game-server-session", h.TerminateSession)
-	http.HandleFunc("/gse/end-process", h.EndProcess)
-	http.HandleFunc("/gse/describe-player-sessions", h.DescribePlayerSessions)
-	http.HandleFunc("/gse/update-player-session-policy", h.UpdatePlayerSessionCreationPolicy)
-	http.HandleFunc("/gse/report-custom-data", h.ReportCustomData)
-	http.HandleFunc("/gse/set-process-health-status", h.SetHealthStatus)
-	http.HandleFunc("/", h.HelloWorld)
+	h.handle("/gse/login", h.Login)
+	h.handle("/gse/logout", h.LoginOut)
+	h.handle("/gse/terminate-game-server-session", h.TerminateSession)
+	h.handle("/gse/end-process", h.EndProcess)
+	h.handle("/gse/describe-player-sessions", h.DescribePlayerSessions)
+	h.handle("/gse/update-player-session-policy", h.UpdatePlayerSessionCreationPolicy)
+	h.handle("/gse/report-custom-data", h.ReportCustomData)
+	h.handle("/gse/set-process-health-status", h.SetHealthStatus)
+	h.handle("/gse/events", h.Events, http.MethodGet)
+	h.handle("/", h.HelloWorld)
+	h.handle("/openapi.json", h.OpenApi, http.MethodGet)
+	h.handle("/metrics", h.Metrics, http.MethodGet)
+	h.handle("/healthz", h.Healthz, http.MethodGet, http.MethodHead)
+	h.handle("/readyz", h.Readyz, http.MethodGet, http.MethodHead)
+	if h.standalone {
+		h.registerStandalone()
+	}
+
+	h.registerApiV1()
+}
+
+// handle registers handler on the default mux, limited to methods when any
+// are given.
+func (h *httpProcess) handle(pattern string, handler http.HandlerFunc, methods ...string) {
+	if len(methods) > 0 {
+		handler = allowMethods(handler, methods...)
+	}
+	h.routes = append(h.routes, route{pattern: pattern, methods: methods})
+	http.HandleFunc(pattern, handler)
 }
 
 func (h *httpProcess) StartHttpServer() {
-	listen, err := net.Listen("tcp4", ":")
+	listen, err := net.Listen("tcp4", h.listenAddr)
 	if err != nil {
 		logger.Fatal("http fail to listen", zap.Error(err))
 	}
@@ -65,9 +135,19 @@ func (h *httpProcess) StartHttpServer() {
 
 	logger.Info("http listen port is", zap.Int("port", h.httpPort))
 
+	host, _, _ := net.SplitHostPort(addr)
+	if ip := net.ParseIP(host); h.auth == nil && (ip == nil || !ip.IsLoopback()) {
+		logger.Warn("http server listens beyond loopback without token auth", zap.String("addr", addr))
+	}
+
 	h.registerApi()
 	logger.Info("start http server success")
-	go http.Serve(listen, nil)
+
+	var handler http.Handler = processMiddleware(http.DefaultServeMux)
+	if h.auth != nil {
+		handler = h.auth.middleware(handler)
+	}
+	go http.Serve(listen, handler)
 }
 
 func (h *httpProcess) GetHttpPort() int {
@@ -85,6 +165,25 @@ func (h *httpProcess) writeResp(code int32, message string, result interface{})
 	return string(resultStr), err
 }
 
+// writeResult 兼容旧接口：HTTP 状态码始终为 200，错误码放在 code 字段里
+func (h *httpProcess) writeResult(w http.ResponseWriter, result interface{}, err error) {
+	if err != nil {
+		code := int32(http.StatusInternalServerError)
+		errMsg := err.Error()
+		st, ok := status.FromError(err)
+		if ok {
+			errMsg = st.Message()
+			code = int32(st.Code())
+		}
+		resp, _ := h.writeResp(code, errMsg, nil)
+		fmt.Fprintf(w, "%s", resp)
+		return
+	}
+
+	resp, _ := h.writeResp(SUCCESS, SUCCESSMSG, result)
+	fmt.Fprintf(w, "%s", resp)
+}
+
 func (h *httpProcess) getContext() context.Context {
 	return context.Background()
 }
@@ -92,103 +191,59 @@ func (h *httpProcess) getContext() context.Context {
 func (h *httpProcess) Login(w http.ResponseWriter, req *http.Request) {
 	playSessionId := req.URL.Query().Get("playerSessionId")
 
-	if playSessionId == "" {
-		resp, _ := h.writeResp(http.StatusBadRequest, "playerSessionId cant be empty", nil)
+	if err := gsemanager.ValidatePlayerSessionId(playSessionId); err != nil {
+		resp, _ := h.writeResp(http.StatusBadRequest, status.Convert(err).Message(), nil)
 		fmt.Fprintf(w, "%s", resp)
 		return
 	}
 
-	gseManager := gsemanager.GetGseManager()
-	_, err := gseManager.AcceptPlayerSession(playSessionId)
-
+	gseManager, err := gseManagerFor(req.Context())
 	if err != nil {
-		code := int32(http.StatusInternalServerError)
-		errMsg := err.Error()
-		st, ok := status.FromError(err)
-		if ok {
-			errMsg = st.Message()
-			code = int32(st.Code())
-		}
-		resp, _ := h.writeResp(code, errMsg, nil)
-		fmt.Fprintf(w, "%s", resp)
+		h.writeResult(w, nil, err)
 		return
 	}
+	err = gseManager.AcceptPlayerSession(playSessionId)
 
-	successMsg, _ := h.writeResp(SUCCESS, SUCCESSMSG, nil)
-	fmt.Fprintf(w, "%s", successMsg)
-	return
+	h.writeResult(w, nil, err)
 }
 
 func (h *httpProcess) LoginOut(w http.ResponseWriter, req *http.Request) {
 	playSessionId := req.URL.Query().Get("playerSessionId")
 
-	if playSessionId == "" {
-		resp, _ := h.writeResp(http.StatusBadRequest, "playerSessionId cant be empty", nil)
+	if err := gsemanager.ValidatePlayerSessionId(playSessionId); err != nil {
+		resp, _ := h.writeResp(http.StatusBadRequest, status.Convert(err).Message(), nil)
 		fmt.Fprintf(w, "%s", resp)
 		return
 	}
 
-	gseManager := gsemanager.GetGseManager()
-	_, err := gseManager.RemovePlayerSession(playSessionId)
+	gseManager, err := gseManagerFor(req.Context())
 	if err != nil {
-		code := int32(http.StatusInternalServerError)
-		errMsg := err.Error()
-		st, ok := status.FromError(err)
-		if ok {
-			errMsg = st.Message()
-			code = int32(st.Code())
-		}
-		resp, _ := h.writeResp(code, errMsg, nil)
-		fmt.Fprintf(w, "%s", resp)
+		h.writeResult(w, nil, err)
 		return
 	}
-
-	successMsg, _ := h.writeResp(SUCCESS, SUCCESSMSG, nil)
-	fmt.Fprintf(w, "%s", successMsg)
-	return
+	err = gseManager.RemovePlayerSession(playSessionId)
+	h.writeResult(w, nil, err)
 }
 
 func (h *httpProcess) TerminateSession(w http.ResponseWriter, req *http.Request) {
-	gseManager := gsemanager.GetGseManager()
-	_, err := gseManager.TerminateGameServerSession()
-
+	gseManager, err := gseManagerFor(req.Context())
 	if err != nil {
-		code := int32(http.StatusInternalServerError)
-		errMsg := err.Error()
-		st, ok := status.FromError(err)
-		if ok {
-			errMsg = st.Message()
-			code = int32(st.Code())
-		}
-		resp, _ := h.writeResp(code, errMsg, nil)
-		fmt.Fprintf(w, "%s", resp)
+		h.writeResult(w, nil, err)
 		return
 	}
+	err = gseManager.TerminateGameServerSession()
 
-	successMsg, _ := h.writeResp(SUCCESS, SUCCESSMSG, nil)
-	fmt.Fprintf(w, "%s", successMsg)
-	return
+	h.writeResult(w, nil, err)
 }
 
 func (h *httpProcess) EndProcess(w http.ResponseWriter, req *http.Request) {
-	gseManager := gsemanager.GetGseManager()
-	_, err := gseManager.ProcessEnding()
+	gseManager, err := gseManagerFor(req.Context())
 	if err != nil {
-		code := int32(http.StatusInternalServerError)
-		errMsg := err.Error()
-		st, ok := status.FromError(err)
-		if ok {
-			errMsg = st.Message()
-			code = int32(st.Code())
-		}
-		resp, _ := h.writeResp(code, errMsg, nil)
-		fmt.Fprintf(w, "%s", resp)
+		h.writeResult(w, nil, err)
 		return
 	}
-
-	successMsg, _ := h.writeResp(SUCCESS, SUCCESSMSG, nil)
-	fmt.Fprintf(w, "%s", successMsg)
-	return
+	err = gseManager.ProcessEnding()
+	h.writeResult(w, nil, err)
 }
 
 func (h *httpProcess) DescribePlayerSessions(w http.ResponseWriter, req *http.Request) {
@@ -198,54 +253,52 @@ func (h *httpProcess) DescribePlayerSessions(w http.ResponseWriter, req *http.Re
 	playerSessionStatusFilter := req.URL.Query().Get("playerSessionStatusFilter")
 	nextToken := req.URL.Query().Get("nextToken")
 	limitStr := req.URL.Query().Get("limit")
-	limit, _ := strconv.Atoi(limitStr)
 
-	gseManager := gsemanager.GetGseManager()
+	limit := 0
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			resp, _ := h.writeResp(http.StatusBadRequest, "limit必须是非负整数", nil)
+			fmt.Fprintf(w, "%s", resp)
+			return
+		}
+	}
+	if _, err := gsemanager.ParsePlayerSessionStatus(playerSessionStatusFilter); err != nil {
+		resp, _ := h.writeResp(http.StatusBadRequest, status.Convert(err).Message(), nil)
+		fmt.Fprintf(w, "%s", resp)
+		return
+	}
+
+	gseManager, err := gseManagerFor(req.Context())
+	if err != nil {
+		h.writeResult(w, nil, err)
+		return
+	}
 	resp, err := gseManager.DescribePlayerSessions(gameServerSessionId, playerId, playerSessionId, playerSessionStatusFilter,
 		nextToken, int32(limit))
 
 	logger.Info("DescribePlayerSessions resp is ", zap.Any("resp", resp))
 
+	h.writeResult(w, resp, err)
+}
+
+func (h *httpProcess) UpdatePlayerSessionCreationPolicy(w http.ResponseWriter, req *http.Request) {
+	newPolicy, err := gsemanager.ParsePlayerSessionCreationPolicy(req.URL.Query().Get("newPlayerSessionCreationPolicy"))
 	if err != nil {
-		code := int32(http.StatusInternalServerError)
-		errMsg := err.Error()
-		st, ok := status.FromError(err)
-		if ok {
-			errMsg = st.Message()
-			code = int32(st.Code())
-		}
-		resp, _ := h.writeResp(code, errMsg, nil)
+		resp, _ := h.writeResp(http.StatusBadRequest, status.Convert(err).Message(), nil)
 		fmt.Fprintf(w, "%s", resp)
 		return
 	}
 
-	result, _ := h.writeResp(SUCCESS, SUCCESSMSG, resp)
-	fmt.Fprintf(w, "%s", result)
-	return
-}
-
-func (h *httpProcess) UpdatePlayerSessionCreationPolicy(w http.ResponseWriter, req *http.Request) {
-	newPolicy := req.URL.Query().Get("newPlayerSessionCreationPolicy")
-
-	gseManager := gsemanager.GetGseManager()
-	_, err := gseManager.UpdatePlayerSessionCreationPolicy(newPolicy)
-
+	gseManager, err := gseManagerFor(req.Context())
 	if err != nil {
-		code := int32(http.StatusInternalServerError)
-		errMsg := err.Error()
-		st, ok := status.FromError(err)
-		if ok {
-			errMsg = st.Message()
-			code = int32(st.Code())
-		}
-		resp, _ := h.writeResp(code, errMsg, nil)
-		fmt.Fprintf(w, "%s", resp)
+		h.writeResult(w, nil, err)
 		return
 	}
+	err = gseManager.UpdatePlayerSessionCreationPolicy(newPolicy)
 
-	successMsg, _ := h.writeResp(SUCCESS, SUCCESSMSG, nil)
-	fmt.Fprintf(w, "%s", successMsg)
-	return
+	h.writeResult(w, nil, err)
 }
 
 func (h *httpProcess) ReportCustomData(w http.ResponseWriter, req *http.Request) {
@@ -261,36 +314,26 @@ func (h *httpProcess) ReportCustomData(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	gseManager := gsemanager.GetGseManager()
-	_, err := gseManager.ReportCustomData(int32(currentCustomCount), int32(maxCustomCount))
-
+	gseManager, err := gseManagerFor(req.Context())
 	if err != nil {
-		code := int32(http.StatusInternalServerError)
-		errMsg := err.Error()
-		st, ok := status.FromError(err)
-		if ok {
-			errMsg = st.Message()
-			code = int32(st.Code())
-		}
-		resp, _ := h.writeResp(code, errMsg, nil)
-		fmt.Fprintf(w, "%s", resp)
+		h.writeResult(w, nil, err)
 		return
 	}
+	err = gseManager.ReportCustomData(int32(currentCustomCount), int32(maxCustomCount))
 
-	successMsg, _ := h.writeResp(SUCCESS, SUCCESSMSG, nil)
-	fmt.Fprintf(w, "%s", successMsg)
-	return
+	h.writeResult(w, nil, err)
 }
 
 func (h *httpProcess) SetHealthStatus(w http.ResponseWriter, req *http.Request) {
 	statusStr := req.URL.Query().Get("healthStatus")
 	status, _ := (strconv.Atoi(statusStr))
 
-	if status == 0 {
-		rpcServerIns.healthStatus = false
-	} else {
-		rpcServerIns.healthStatus = true
+	service, err := rpcServiceFor(grpcPortFrom(req.Context()))
+	if err != nil {
+		h.writeResult(w, nil, err)
+		return
 	}
+	service.SetHealthStatus(status != 0)
 
 	successMsg, _ := h.writeResp(SUCCESS, SUCCESSMSG, nil)
 	fmt.Fprintf(w, "%s", successMsg)
@@ -0,0 +1,325 @@
+package api
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"supertuxkart/gsemanager"
+	"supertuxkart/localsdk"
+	"time"
+)
+
+// localService 是游戏进程调用的本地 gRPC 服务，/v1 HTTP 接口由 grpc-gateway 转发到这里
+type localService struct {
+	// grpcPort 是所在 grpc server 的端口，即调用方所属的进程；0 表示由 HTTP 请求指定
+	grpcPort int
+}
+
+func (l *localService) grpcPortOf(ctx context.Context) int {
+	if port := grpcPortFrom(ctx); port != 0 {
+		return port
+	}
+	return l.grpcPort
+}
+
+func (l *localService) gseManager(ctx context.Context) (*gsemanager.GseManager, error) {
+	return gsemanager.GetGseManagerByGrpcPort(l.grpcPortOf(ctx))
+}
+
+func (l *localService) rpcService(ctx context.Context) (*rpcService, error) {
+	return rpcServiceFor(l.grpcPortOf(ctx))
+}
+
+func (l *localService) Login(ctx context.Context, req *localsdk.PlayerSessionRequest) (*localsdk.LocalResponse, error) {
+	if err := gsemanager.ValidatePlayerSessionId(req.PlayerSessionId); err != nil {
+		return nil, err
+	}
+
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = gseManager.AcceptPlayerSession(req.PlayerSessionId)
+	if err != nil {
+		return nil, err
+	}
+	return new(localsdk.LocalResponse), nil
+}
+
+func (l *localService) Logout(ctx context.Context, req *localsdk.PlayerSessionRequest) (*localsdk.LocalResponse, error) {
+	if err := gsemanager.ValidatePlayerSessionId(req.PlayerSessionId); err != nil {
+		return nil, err
+	}
+
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = gseManager.RemovePlayerSession(req.PlayerSessionId)
+	if err != nil {
+		return nil, err
+	}
+	return new(localsdk.LocalResponse), nil
+}
+
+func (l *localService) BatchLogin(ctx context.Context, req *localsdk.BatchPlayerSessionRequest) (*localsdk.BatchPlayerSessionResponse, error) {
+	if err := validateBatch(req.PlayerSessionIds); err != nil {
+		return nil, err
+	}
+
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := gseManager.AcceptPlayerSessions(req.PlayerSessionIds, gsemanager.DefaultBatchParallelism)
+	return toBatchResponse(results), nil
+}
+
+func (l *localService) BatchLogout(ctx context.Context, req *localsdk.BatchPlayerSessionRequest) (*localsdk.BatchPlayerSessionResponse, error) {
+	if err := validateBatch(req.PlayerSessionIds); err != nil {
+		return nil, err
+	}
+
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := gseManager.RemovePlayerSessions(req.PlayerSessionIds, gsemanager.DefaultBatchParallelism)
+	return toBatchResponse(results), nil
+}
+
+func validateBatch(playerSessionIds []string) error {
+	if len(playerSessionIds) == 0 {
+		return status.Error(codes.InvalidArgument, "playerSessionIds cant be empty")
+	}
+	if len(playerSessionIds) > gsemanager.MaxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "at most %d playerSessionIds per call, got %d",
+			gsemanager.MaxBatchSize, len(playerSessionIds))
+	}
+	return nil
+}
+
+func toBatchResponse(results []gsemanager.PlayerSessionResult) *localsdk.BatchPlayerSessionResponse {
+	resp := new(localsdk.BatchPlayerSessionResponse)
+	for _, r := range results {
+		st := status.Convert(r.Err)
+		resp.Results = append(resp.Results, &localsdk.PlayerSessionResult{
+			PlayerSessionId: r.PlayerSessionId,
+			Code:            st.Code().String(),
+			Message:         st.Message(),
+		})
+	}
+	return resp
+}
+
+func (l *localService) DescribePlayerSessions(ctx context.Context, req *localsdk.DescribePlayerSessionsRequest) (*localsdk.DescribePlayerSessionsResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be a non-negative integer, got %d", req.Limit)
+	}
+	if _, err := gsemanager.ParsePlayerSessionStatus(req.PlayerSessionStatusFilter); err != nil {
+		return nil, err
+	}
+
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := gseManager.DescribePlayerSessions(req.GameServerSessionId, req.PlayerId,
+		req.PlayerSessionId, req.PlayerSessionStatusFilter, req.NextToken, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &localsdk.DescribePlayerSessionsResponse{
+		NextToken: resp.NextToken,
+	}
+	for _, ps := range resp.PlayerSessions {
+		result.PlayerSessions = append(result.PlayerSessions, toLocalPlayerSession(ps))
+	}
+	return result, nil
+}
+
+func toLocalPlayerSession(ps *gsemanager.PlayerSession) *localsdk.PlayerSession {
+	return &localsdk.PlayerSession{
+		PlayerSessionId:     ps.PlayerSessionId,
+		PlayerId:            ps.PlayerId,
+		GameServerSessionId: ps.GameServerSessionId,
+		FleetId:             ps.FleetId,
+		IpAddress:           ps.IpAddress,
+		Status:              string(ps.Status),
+		CreationTime:        ps.CreationTime,
+		TerminationTime:     ps.TerminationTime,
+		Port:                ps.Port,
+		PlayerData:          ps.PlayerData,
+		DnsName:             ps.DnsName,
+	}
+}
+
+func (l *localService) QueryCachedPlayerSessions(ctx context.Context, req *localsdk.QueryCachedPlayerSessionsRequest) (*localsdk.QueryCachedPlayerSessionsResponse, error) {
+	statusFilter, err := gsemanager.ParsePlayerSessionStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions := gseManager.QueryPlayerSessions(gsemanager.PlayerSessionFilter{
+		PlayerSessionId: req.PlayerSessionId,
+		PlayerId:        req.PlayerId,
+		Status:          statusFilter,
+	})
+
+	resp := new(localsdk.QueryCachedPlayerSessionsResponse)
+	for _, ps := range sessions {
+		resp.PlayerSessions = append(resp.PlayerSessions, &localsdk.CachedPlayerSession{
+			PlayerSessionId:     ps.PlayerSessionId,
+			PlayerId:            ps.PlayerId,
+			GameServerSessionId: ps.GameServerSessionId,
+			Status:              string(ps.Status),
+			Connected:           ps.Connected,
+			UpdateTime:          ps.UpdateTime.UnixNano() / int64(time.Millisecond),
+		})
+	}
+	return resp, nil
+}
+
+func (l *localService) GetGameServerSession(ctx context.Context, req *localsdk.GetGameServerSessionRequest) (*localsdk.GameServerSession, error) {
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gss := gseManager.GetGameServerSession()
+	if gss == nil {
+		return nil, status.Error(codes.NotFound, "no game server session yet")
+	}
+
+	resp := &localsdk.GameServerSession{
+		GameServerSessionId:   gss.GameServerSessionId,
+		FleetId:               gss.FleetId,
+		Name:                  gss.Name,
+		MaxPlayers:            gss.MaxPlayers,
+		Joinable:              gss.Joinable,
+		Port:                  gss.Port,
+		IpAddress:             gss.IpAddress,
+		GameServerSessionData: gss.GameServerSessionData,
+		MatchmakerData:        gss.MatchmakerData,
+		DnsName:               gss.DnsName,
+	}
+	for _, p := range gss.GameProperties {
+		resp.GameProperties = append(resp.GameProperties, &localsdk.GameProperty{Key: p.Key, Value: p.Value})
+	}
+	return resp, nil
+}
+
+func (l *localService) GetStatus(ctx context.Context, req *localsdk.GetStatusRequest) (*localsdk.WrapperStatus, error) {
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	service, err := l.rpcService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st := gseManager.Status()
+
+	resp := &localsdk.WrapperStatus{
+		State:                       string(st.State),
+		Pid:                         int32(st.Pid),
+		GameServerSessionId:         st.GameServerSessionId,
+		TerminationTime:             st.TerminationTime,
+		ClientPort:                  int32(st.ClientPort),
+		GrpcPort:                    int32(st.GrpcPort),
+		HttpPort:                    int32(st.HttpPort),
+		StartTime:                   st.StartTime.UnixNano() / int64(time.Millisecond),
+		UptimeSeconds:               int64(time.Since(st.StartTime) / time.Second),
+		Healthy:                     service.HealthStatus(),
+		PlayerSessionCreationPolicy: string(st.Policy),
+		Draining:                    st.Draining,
+		MaxPlayers:                  int32(st.MaxPlayers),
+		PlayerSessions:              int32(st.PlayerSessions),
+		TerminationPhase:            string(st.Termination.Phase),
+		TerminationError:            st.Termination.LastError,
+	}
+	if !st.Termination.Deadline.IsZero() {
+		resp.TerminationDeadline = st.Termination.Deadline.UnixNano() / int64(time.Millisecond)
+	}
+	return resp, nil
+}
+
+func (l *localService) UpdatePlayerSessionCreationPolicy(ctx context.Context, req *localsdk.UpdatePlayerSessionCreationPolicyRequest) (*localsdk.LocalResponse, error) {
+	policy, err := gsemanager.ParsePlayerSessionCreationPolicy(req.NewPlayerSessionCreationPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = gseManager.UpdatePlayerSessionCreationPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	return new(localsdk.LocalResponse), nil
+}
+
+func (l *localService) TerminateGameServerSession(ctx context.Context, req *localsdk.TerminateGameServerSessionRequest) (*localsdk.LocalResponse, error) {
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = gseManager.TerminateGameServerSession()
+	if err != nil {
+		return nil, err
+	}
+	return new(localsdk.LocalResponse), nil
+}
+
+func (l *localService) ProcessEnding(ctx context.Context, req *localsdk.ProcessEndingRequest) (*localsdk.LocalResponse, error) {
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = gseManager.ProcessEnding()
+	if err != nil {
+		return nil, err
+	}
+	return new(localsdk.LocalResponse), nil
+}
+
+func (l *localService) ReportCustomData(ctx context.Context, req *localsdk.ReportCustomDataRequest) (*localsdk.LocalResponse, error) {
+	gseManager, err := l.gseManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = gseManager.ReportCustomData(req.CurrentCustomCount, req.MaxCustomCount)
+	if err != nil {
+		return nil, err
+	}
+	return new(localsdk.LocalResponse), nil
+}
+
+func (l *localService) PushCustomData(ctx context.Context, req *localsdk.ReportCustomDataRequest) (*localsdk.LocalResponse, error) {
+	reporter := gsemanager.GetCustomDataReporter()
+	if source := reporter.Source(); source != gsemanager.CustomDataSourcePush {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"custom data source is %q, start the wrapper with -custom-data-source=push", source)
+	}
+
+	reporter.Update(gsemanager.CustomData{
+		CurrentCustomCount: req.CurrentCustomCount,
+		MaxCustomCount:     req.MaxCustomCount,
+	})
+	return new(localsdk.LocalResponse), nil
+}
+
+func (l *localService) SetHealthStatus(ctx context.Context, req *localsdk.SetHealthStatusRequest) (*localsdk.LocalResponse, error) {
+	service, err := l.rpcService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	service.SetHealthStatus(req.HealthStatus)
+	return new(localsdk.LocalResponse), nil
+}
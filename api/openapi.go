@@ -0,0 +1,18 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openApiSpec describes every route registered in registerApi. Keep it in
+// sync with the handlers and with the client in package apiclient; the
+// tests of both packages check it.
+//
+//go:embed openapi.json
+var openApiSpec []byte
+
+func (h *httpProcess) OpenApi(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openApiSpec)
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"supertuxkart/localsdk"
+	"sync"
+	"testing"
+	"time"
+)
+
+type openApiOperation struct {
+	Responses map[string]json.RawMessage `json:"responses"`
+	Scope     string                     `json:"x-gse-scope"`
+}
+
+type openApiDoc struct {
+	Paths map[string]map[string]openApiOperation `json:"paths"`
+}
+
+func loadOpenApi(t *testing.T) *openApiDoc {
+	t.Helper()
+
+	doc := new(openApiDoc)
+	if err := json.Unmarshal(openApiSpec, doc); err != nil {
+		t.Fatalf("openapi.json: %v", err)
+	}
+	return doc
+}
+
+// operations returns "METHOD path" for every operation in the spec.
+func (doc *openApiDoc) operations() []string {
+	var ops []string
+	for path, methods := range doc.Paths {
+		for method := range methods {
+			ops = append(ops, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+var (
+	testHttpOnce sync.Once
+	testHttp     *httpProcess
+)
+
+// registeredApi registers the routes once on the default mux, standalone
+// ones included, as StartHttpServer does.
+func registeredApi() *httpProcess {
+	testHttpOnce.Do(func() {
+		testHttp = NewHttpProcess("")
+		testHttp.EnableStandalone()
+		testHttp.registerApi()
+	})
+	return testHttp
+}
+
+// registeredOperations returns "METHOD path" for every route registerApi
+// adds: the default mux routes, legacy ones counting as GET, and the /v1
+// routes grpc-gateway generates from the google.api.http options.
+func registeredOperations(t *testing.T) []string {
+	t.Helper()
+
+	var ops []string
+	for _, r := range registeredApi().routes {
+		methods := r.methods
+		if len(methods) == 0 {
+			methods = []string{http.MethodGet}
+		}
+		for _, method := range methods {
+			ops = append(ops, method+" "+r.pattern)
+		}
+	}
+
+	services := localsdk.File_local_grpc_service_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			rule, _ := proto.GetExtension(methods.Get(j).Options(), annotations.E_Http).(*annotations.HttpRule)
+			if rule == nil {
+				continue
+			}
+			switch {
+			case rule.GetGet() != "":
+				ops = append(ops, http.MethodGet+" "+rule.GetGet())
+			case rule.GetPost() != "":
+				ops = append(ops, http.MethodPost+" "+rule.GetPost())
+			case rule.GetPut() != "":
+				ops = append(ops, http.MethodPut+" "+rule.GetPut())
+			case rule.GetDelete() != "":
+				ops = append(ops, http.MethodDelete+" "+rule.GetDelete())
+			case rule.GetPatch() != "":
+				ops = append(ops, http.MethodPatch+" "+rule.GetPatch())
+			default:
+				t.Errorf("%s: unsupported google.api.http pattern", methods.Get(j).FullName())
+			}
+		}
+	}
+	ops = append(ops, http.MethodGet+" "+streamPlayerSessionsPath)
+
+	sort.Strings(ops)
+	return ops
+}
+
+func TestOpenApiMatchesRoutes(t *testing.T) {
+	documented := make(map[string]bool)
+	for _, op := range loadOpenApi(t).operations() {
+		documented[op] = true
+	}
+	registered := make(map[string]bool)
+	for _, op := range registeredOperations(t) {
+		registered[op] = true
+	}
+
+	for op := range registered {
+		if !documented[op] {
+			t.Errorf("%s is registered but not in openapi.json", op)
+		}
+	}
+	for op := range documented {
+		if !registered[op] {
+			t.Errorf("%s is in openapi.json but not registered", op)
+		}
+	}
+}
+
+func TestOpenApiStatusCodes(t *testing.T) {
+	doc := loadOpenApi(t)
+	registeredApi()
+	server := httptest.NewServer(processMiddleware(http.DefaultServeMux))
+	defer server.Close()
+
+	// 测试里没有游戏进程，大部分接口返回 503 Unavailable，流式接口只看响应头
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			code, err := requestStatus(server.URL, strings.ToUpper(method), path)
+			if err != nil {
+				t.Errorf("%s %s: %v", method, path, err)
+				continue
+			}
+
+			if _, ok := op.Responses[strconv.Itoa(code)]; ok {
+				continue
+			}
+			if _, ok := op.Responses["default"]; ok && code >= 400 {
+				continue
+			}
+			t.Errorf("%s %s answered %d, not documented in openapi.json", method, path, code)
+		}
+	}
+}
+
+func requestStatus(baseURL, method, path string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	body := ""
+	if method == http.MethodPost || method == http.MethodPut {
+		body = "{}"
+	}
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, strings.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func TestOpenApiScopes(t *testing.T) {
+	doc := loadOpenApi(t)
+
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			switch {
+			case op.Scope == "none":
+				if !probeEndpoints[path] {
+					t.Errorf("%s %s: openapi.json says no token, but auth checks it", method, path)
+				}
+			case probeEndpoints[path]:
+				t.Errorf("%s %s: auth skips it, but openapi.json says x-gse-scope %q", method, path, op.Scope)
+			case requiredScope(path) != op.Scope:
+				t.Errorf("%s %s: openapi.json says x-gse-scope %q, auth requires %q", method, path, op.Scope, requiredScope(path))
+			}
+		}
+	}
+}
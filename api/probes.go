@@ -0,0 +1,115 @@
+package api
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"supertuxkart/gsemanager"
+	"supertuxkart/logger"
+)
+
+// /healthz 和 /readyz 给 Kubernetes 探针用，不需要 token
+
+// probeEndpoints skip token auth; probes cannot present a bearer token.
+var probeEndpoints = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// StartProbeServer serves only /healthz and /readyz on addr, so kubelet can
+// reach the probes on a pod address while the API stays on loopback.
+func (h *httpProcess) StartProbeServer(addr string) error {
+	listen, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", allowMethods(h.Healthz, http.MethodGet, http.MethodHead))
+	mux.HandleFunc("/readyz", allowMethods(h.Readyz, http.MethodGet, http.MethodHead))
+
+	logger.Info("probe listen address is", zap.String("addr", listen.Addr().String()))
+	go http.Serve(listen, processMiddleware(mux))
+	return nil
+}
+
+type probeResponse struct {
+	// Status is ok or unavailable.
+	Status   string `json:"status"`
+	State    string `json:"state"`
+	Healthy  bool   `json:"healthy"`
+	Draining bool   `json:"draining"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Healthz is the liveness probe: it fails only while the game reports itself
+// unhealthy, the state OnHealthCheck and grpc.health.v1 answer with.
+func (h *httpProcess) Healthz(w http.ResponseWriter, req *http.Request) {
+	resp, err := newProbeResponse(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !resp.Healthy {
+		resp.Reason = "the game reported itself unhealthy"
+	}
+	writeProbe(w, resp)
+}
+
+// Readyz is the readiness probe: it passes once the game listens and until
+// the process starts draining or terminating, while it is healthy.
+func (h *httpProcess) Readyz(w http.ResponseWriter, req *http.Request) {
+	resp, err := newProbeResponse(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	state := gsemanager.LifecycleState(resp.State)
+	switch {
+	case !resp.Healthy:
+		resp.Reason = "the game reported itself unhealthy"
+	case !state.AtLeast(gsemanager.StateServerReady):
+		resp.Reason = "the game is not listening yet"
+	case state.AtLeast(gsemanager.StateTerminating):
+		resp.Reason = "the process is terminating"
+	case resp.Draining:
+		resp.Reason = "the process is draining"
+	}
+	writeProbe(w, resp)
+}
+
+func newProbeResponse(req *http.Request) (*probeResponse, error) {
+	service, err := rpcServiceFor(grpcPortFrom(req.Context()))
+	if err != nil {
+		return nil, err
+	}
+
+	// ProcessReady 之前还是 STARTING，存活探针照常通过
+	st := gsemanager.Status{State: gsemanager.StateStarting}
+	gseManager, err := gseManagerFor(req.Context())
+	switch {
+	case err == nil:
+		st = gseManager.Status()
+	case status.Code(err) != codes.Unavailable:
+		return nil, err
+	}
+
+	return &probeResponse{
+		State:    string(st.State),
+		Healthy:  service.HealthStatus(),
+		Draining: st.Draining,
+	}, nil
+}
+
+func writeProbe(w http.ResponseWriter, resp *probeResponse) {
+	code := http.StatusOK
+	resp.Status = "ok"
+	if resp.Reason != "" {
+		code = http.StatusServiceUnavailable
+		resp.Status = "unavailable"
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, code, resp)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
+	"supertuxkart/gsemanager"
+	"sync"
+	"time"
+)
+
+// GrpcPortHeader 多进程模式下指定请求属于哪个游戏进程，值为该进程的 GSE_GRPC_PORT
+const GrpcPortHeader = "X-Gse-Grpc-Port"
+
+type grpcPortKey struct{}
+
+var (
+	rpcServicesMu sync.RWMutex
+	rpcServices   = make(map[int]*rpcService)
+)
+
+func registerRpcService(s *rpcService) {
+	rpcServicesMu.Lock()
+	defer rpcServicesMu.Unlock()
+
+	rpcServices[s.grpcPort] = s
+}
+
+// StopGrpcServers gracefully stops the grpc server of every process, each
+// waiting up to timeout.
+func StopGrpcServers(timeout time.Duration) {
+	rpcServicesMu.RLock()
+	services := make([]*rpcService, 0, len(rpcServices))
+	for _, s := range rpcServices {
+		services = append(services, s)
+	}
+	rpcServicesMu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, s := range services {
+		wg.Add(1)
+		go func(s *rpcService) {
+			defer wg.Done()
+			s.StopGrpcServer(timeout)
+		}(s)
+	}
+	wg.Wait()
+}
+
+// rpcServiceFor returns the callback service listening on grpcPort; 0 means
+// the first process. An unknown port is NotFound.
+func rpcServiceFor(grpcPort int) (*rpcService, error) {
+	if grpcPort == 0 {
+		return GetRpcService(), nil
+	}
+
+	rpcServicesMu.RLock()
+	s, ok := rpcServices[grpcPort]
+	rpcServicesMu.RUnlock()
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no game server process listens on grpc port %d", grpcPort)
+	}
+	return s, nil
+}
+
+func grpcPortFrom(ctx context.Context) int {
+	port, _ := ctx.Value(grpcPortKey{}).(int)
+	return port
+}
+
+// gseManagerFor returns the manager of the process ctx is for; without a
+// process it is the first one.
+func gseManagerFor(ctx context.Context) (*gsemanager.GseManager, error) {
+	return gsemanager.GetGseManagerByGrpcPort(grpcPortFrom(ctx))
+}
+
+// processMiddleware reads the process from GrpcPortHeader, or the grpcPort
+// query parameter, into the request context.
+func processMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		value := req.Header.Get(GrpcPortHeader)
+		if value == "" {
+			value = req.URL.Query().Get("grpcPort")
+		}
+		if value == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid %s %q", GrpcPortHeader, value))
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), grpcPortKey{}, port)))
+	})
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"supertuxkart/grpcsdk"
+	"supertuxkart/gsemanager"
+	"time"
+)
+
+// 单机模式下没有 agent 回调，用下面两个接口代替 OnStartGameServerSession 和 OnProcessTerminate
+
+// EnableStandalone registers the routes that stand in for the agent's
+// callbacks. Call it before StartHttpServer.
+func (h *httpProcess) EnableStandalone() {
+	h.standalone = true
+}
+
+func (h *httpProcess) registerStandalone() {
+	h.handle("/gse/standalone/start-game-server-session", h.StartGameServerSession, http.MethodPost)
+	h.handle("/gse/standalone/process-terminate", h.ProcessTerminate, http.MethodPost)
+}
+
+// StartGameServerSession calls OnStartGameServerSession with the
+// GameServerSession JSON in the body. An empty body or missing fields get
+// local defaults: a generated gameServerSessionId, this host and the game port.
+func (h *httpProcess) StartGameServerSession(w http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	gameServerSession := &gsemanager.GameServerSession{}
+	if len(body) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(gameServerSession); err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid game server session: %v", err))
+			return
+		}
+	}
+
+	if gameServerSession.GameServerSessionId == "" {
+		gameServerSession.GameServerSessionId = "local-" + uuid.NewV4().String()
+	}
+	if gameServerSession.IpAddress == "" {
+		gameServerSession.IpAddress = "127.0.0.1"
+	}
+	gseManager, err := gseManagerFor(req.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if gameServerSession.Port == 0 {
+		gameServerSession.Port = int32(gseManager.Status().ClientPort)
+	}
+
+	s, err := rpcServiceFor(grpcPortFrom(req.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.startGameServerSession(req.Context(), gameServerSession); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, _ := json.Marshal(gameServerSession)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+// ProcessTerminate calls OnProcessTerminate. ?terminationTime= is in unix
+// seconds, now when omitted.
+func (h *httpProcess) ProcessTerminate(w http.ResponseWriter, req *http.Request) {
+	terminationTime := time.Now().Unix()
+	if s := req.URL.Query().Get("terminationTime"); s != "" {
+		var err error
+		if terminationTime, err = strconv.ParseInt(s, 10, 64); err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid terminationTime %q", s))
+			return
+		}
+	}
+
+	s, err := rpcServiceFor(grpcPortFrom(req.Context()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	_, err = s.OnProcessTerminate(req.Context(), &grpcsdk.ProcessTerminateRequest{
+		TerminationTime: terminationTime,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,135 @@
+// Package apiclient is a Go client for the wrapper's /v1 HTTP API. The types
+// and methods in client_gen.go are generated from api/openapi.json; run go
+// generate after changing the spec. The event stream is in events.go.
+package apiclient
+
+//go:generate go run ./gen -o client_gen.go ../api/openapi.json
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Environment variables the wrapper sets for the game process. The tokens are
+// only set when the wrapper runs with -http-auth.
+const (
+	PortEnv      = "GSE_HTTP_PORT"
+	TokenEnv     = "GSE_HTTP_TOKEN"
+	ReadTokenEnv = "GSE_HTTP_READ_TOKEN"
+	GrpcPortEnv  = "GSE_GRPC_PORT"
+
+	// GrpcPortHeader tells a wrapper running several game servers which one
+	// the request is for.
+	GrpcPortHeader = "X-Gse-Grpc-Port"
+)
+
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// Token is sent as a bearer token when not empty.
+	Token string
+	// GrpcPort is sent as GrpcPortHeader when not empty.
+	GrpcPort string
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewClientFromEnv builds a client for the wrapper that launched this process.
+func NewClientFromEnv() (*Client, error) {
+	port := os.Getenv(PortEnv)
+	if port == "" {
+		return nil, fmt.Errorf("%s is not set", PortEnv)
+	}
+
+	c := NewClient("http://127.0.0.1:" + port)
+	c.Token = os.Getenv(TokenEnv)
+	c.GrpcPort = os.Getenv(GrpcPortEnv)
+	return c, nil
+}
+
+// Error is the ErrorResponse schema plus the HTTP status it came with.
+type Error struct {
+	HTTPStatus int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.HTTPStatus, e.Code, e.Message)
+}
+
+// do sends query and body as JSON, decodes a 2xx response, or one with a
+// status in also, into out when it is not nil and turns any other status
+// into *Error.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}, also ...int) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if c.GrpcPort != "" {
+		req.Header.Set(GrpcPortHeader, c.GrpcPort)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if (resp.StatusCode < 200 || resp.StatusCode > 299) && !contains(also, resp.StatusCode) {
+		envelope := struct {
+			Error *Error `json:"error"`
+		}{}
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil || envelope.Error == nil {
+			return &Error{HTTPStatus: resp.StatusCode, Code: "Unknown", Message: resp.Status}
+		}
+		envelope.Error.HTTPStatus = resp.StatusCode
+		return envelope.Error
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func contains(statuses []int, status int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
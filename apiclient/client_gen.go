@@ -0,0 +1,418 @@
+// Code generated by apiclient/gen from api/openapi.json. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// BatchPlayerSessionRequest is the BatchPlayerSessionRequest schema.
+type BatchPlayerSessionRequest struct {
+	PlayerSessionIds []string `json:"playerSessionIds"`
+}
+
+// BatchPlayerSessionResponse is the BatchPlayerSessionResponse schema.
+type BatchPlayerSessionResponse struct {
+	Results []*PlayerSessionResult `json:"results,omitempty"`
+}
+
+// CachedPlayerSession is the CachedPlayerSession schema.
+type CachedPlayerSession struct {
+	PlayerSessionId     string `json:"playerSessionId,omitempty"`
+	PlayerId            string `json:"playerId,omitempty"`
+	GameServerSessionId string `json:"gameServerSessionId,omitempty"`
+	Status              string `json:"status,omitempty"`
+	// the player has appeared in the game log
+	Connected bool `json:"connected,omitempty"`
+	// unix milliseconds
+	UpdateTime int64 `json:"updateTime,omitempty,string"`
+}
+
+// CustomDataRequest is the CustomDataRequest schema.
+type CustomDataRequest struct {
+	CurrentCustomCount int32 `json:"currentCustomCount"`
+	MaxCustomCount     int32 `json:"maxCustomCount"`
+}
+
+// DescribePlayerSessionsResponse is the DescribePlayerSessionsResponseV1
+// schema.
+type DescribePlayerSessionsResponse struct {
+	NextToken      string           `json:"nextToken,omitempty"`
+	PlayerSessions []*PlayerSession `json:"playerSessions,omitempty"`
+}
+
+// GameProperty is one of the game properties the session was created with.
+type GameProperty struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// GameServerSession is the GameServerSession schema.
+type GameServerSession struct {
+	GameServerSessionId   string          `json:"gameServerSessionId,omitempty"`
+	FleetId               string          `json:"fleetId,omitempty"`
+	Name                  string          `json:"name,omitempty"`
+	MaxPlayers            int32           `json:"maxPlayers,omitempty"`
+	Joinable              bool            `json:"joinable,omitempty"`
+	GameProperties        []*GameProperty `json:"gameProperties,omitempty"`
+	Port                  int32           `json:"port,omitempty"`
+	IpAddress             string          `json:"ipAddress,omitempty"`
+	GameServerSessionData string          `json:"gameServerSessionData,omitempty"`
+	MatchmakerData        string          `json:"matchmakerData,omitempty"`
+	DnsName               string          `json:"dnsName,omitempty"`
+}
+
+// HealthStatusRequest is the HealthStatusRequest schema.
+type HealthStatusRequest struct {
+	HealthStatus bool `json:"healthStatus"`
+}
+
+// PlayerSession is the PlayerSessionV1 schema.
+type PlayerSession struct {
+	PlayerSessionId     string `json:"playerSessionId,omitempty"`
+	PlayerId            string `json:"playerId,omitempty"`
+	GameServerSessionId string `json:"gameServerSessionId,omitempty"`
+	FleetId             string `json:"fleetId,omitempty"`
+	IpAddress           string `json:"ipAddress,omitempty"`
+	Status              string `json:"status,omitempty"`
+	// proto3 JSON encodes int64 as a string
+	CreationTime int64 `json:"creationTime,omitempty,string"`
+	// proto3 JSON encodes int64 as a string
+	TerminationTime int64  `json:"terminationTime,omitempty,string"`
+	Port            int32  `json:"port,omitempty"`
+	PlayerData      string `json:"playerData,omitempty"`
+	DnsName         string `json:"dnsName,omitempty"`
+}
+
+// PlayerSessionCreationPolicyRequest is the PlayerSessionCreationPolicyRequest
+// schema.
+type PlayerSessionCreationPolicyRequest struct {
+	NewPlayerSessionCreationPolicy string `json:"newPlayerSessionCreationPolicy"`
+}
+
+// PlayerSessionRequest is the PlayerSessionRequest schema.
+type PlayerSessionRequest struct {
+	PlayerSessionId string `json:"playerSessionId"`
+}
+
+// PlayerSessionResult is the PlayerSessionResult schema.
+type PlayerSessionResult struct {
+	PlayerSessionId string `json:"playerSessionId"`
+	// gRPC code name, OK on success
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// ProbeResponse is the ProbeResponse schema.
+type ProbeResponse struct {
+	Status   string `json:"status"`
+	State    string `json:"state"`
+	Healthy  bool   `json:"healthy"`
+	Draining bool   `json:"draining"`
+	// why the probe fails, absent when it passes
+	Reason string `json:"reason,omitempty"`
+}
+
+// QueryCachedPlayerSessionsResponse is the QueryCachedPlayerSessionsResponse
+// schema.
+type QueryCachedPlayerSessionsResponse struct {
+	PlayerSessions []*CachedPlayerSession `json:"playerSessions,omitempty"`
+}
+
+// WrapperStatus is the WrapperStatus schema.
+type WrapperStatus struct {
+	State string `json:"state,omitempty"`
+	// the game server process
+	Pid                 int32  `json:"pid,omitempty"`
+	GameServerSessionId string `json:"gameServerSessionId,omitempty"`
+	// from OnProcessTerminate, 0 until then
+	TerminationTime int64 `json:"terminationTime,omitempty,string"`
+	ClientPort      int32 `json:"clientPort,omitempty"`
+	GrpcPort        int32 `json:"grpcPort,omitempty"`
+	HttpPort        int32 `json:"httpPort,omitempty"`
+	// unix milliseconds
+	StartTime                   int64  `json:"startTime,omitempty,string"`
+	UptimeSeconds               int64  `json:"uptimeSeconds,omitempty,string"`
+	Healthy                     bool   `json:"healthy,omitempty"`
+	PlayerSessionCreationPolicy string `json:"playerSessionCreationPolicy,omitempty"`
+	Draining                    bool   `json:"draining,omitempty"`
+	// the enforced cap, 0 when none
+	MaxPlayers int32 `json:"maxPlayers,omitempty"`
+	// RESERVED and ACTIVE sessions
+	PlayerSessions int32 `json:"playerSessions,omitempty"`
+	// progress of the shutdown OnProcessTerminate started, empty before
+	TerminationPhase string `json:"terminationPhase,omitempty"`
+	// unix milliseconds the shutdown must finish by, 0 before
+	// OnProcessTerminate
+	TerminationDeadline int64 `json:"terminationDeadline,omitempty,string"`
+	// last agent call that failed during the shutdown
+	TerminationError string `json:"terminationError,omitempty"`
+}
+
+// DescribePlayerSessionsParams are the query parameters of DescribePlayerSessions.
+type DescribePlayerSessionsParams struct {
+	GameServerSessionId string
+	PlayerId            string
+	PlayerSessionId     string
+	// RESERVED, ACTIVE, COMPLETED or TIMEDOUT
+	PlayerSessionStatusFilter string
+	NextToken                 string
+	Limit                     int32
+}
+
+func (p *DescribePlayerSessionsParams) values() url.Values {
+	query := url.Values{}
+	if p.GameServerSessionId != "" {
+		query.Set("gameServerSessionId", p.GameServerSessionId)
+	}
+	if p.PlayerId != "" {
+		query.Set("playerId", p.PlayerId)
+	}
+	if p.PlayerSessionId != "" {
+		query.Set("playerSessionId", p.PlayerSessionId)
+	}
+	if p.PlayerSessionStatusFilter != "" {
+		query.Set("playerSessionStatusFilter", p.PlayerSessionStatusFilter)
+	}
+	if p.NextToken != "" {
+		query.Set("nextToken", p.NextToken)
+	}
+	if p.Limit != 0 {
+		query.Set("limit", strconv.FormatInt(int64(p.Limit), 10))
+	}
+	return query
+}
+
+// QueryCachedPlayerSessionsParams are the query parameters of QueryCachedPlayerSessions.
+type QueryCachedPlayerSessionsParams struct {
+	PlayerSessionId string
+	PlayerId        string
+	// RESERVED, ACTIVE, COMPLETED or TIMEDOUT
+	Status string
+}
+
+func (p *QueryCachedPlayerSessionsParams) values() url.Values {
+	query := url.Values{}
+	if p.PlayerSessionId != "" {
+		query.Set("playerSessionId", p.PlayerSessionId)
+	}
+	if p.PlayerId != "" {
+		query.Set("playerId", p.PlayerId)
+	}
+	if p.Status != "" {
+		query.Set("status", p.Status)
+	}
+	return query
+}
+
+// StandaloneProcessTerminateParams are the query parameters of StandaloneProcessTerminate.
+type StandaloneProcessTerminateParams struct {
+	// unix seconds, now when omitted
+	TerminationTime int64
+}
+
+func (p *StandaloneProcessTerminateParams) values() url.Values {
+	query := url.Values{}
+	if p.TerminationTime != 0 {
+		query.Set("terminationTime", strconv.FormatInt(p.TerminationTime, 10))
+	}
+	return query
+}
+
+// AcceptPlayerSession calls AcceptPlayerSessionV1, POST
+// /v1/player-sessions/accept. Accept a player session.
+//
+// Rejected with 429 ResourceExhausted, without calling the agent, when the
+// reserved and active sessions already reach the session's MaxPlayers (or the
+// lower -max-players).
+func (c *Client) AcceptPlayerSession(ctx context.Context, body *PlayerSessionRequest) error {
+	return c.do(ctx, http.MethodPost, "/v1/player-sessions/accept", nil, body, nil)
+}
+
+// AcceptPlayerSessions calls BatchLoginV1, POST
+// /v1/player-sessions/batch-accept. Accept up to 100 player sessions.
+//
+// IDs are validated and accepted concurrently; the response has one result per
+// ID in request order.
+func (c *Client) AcceptPlayerSessions(ctx context.Context, body *BatchPlayerSessionRequest) (*BatchPlayerSessionResponse, error) {
+	resp := new(BatchPlayerSessionResponse)
+	if err := c.do(ctx, http.MethodPost, "/v1/player-sessions/batch-accept", nil, body, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DescribePlayerSessions calls DescribePlayerSessionsV1, GET
+// /v1/player-sessions. List player sessions.
+func (c *Client) DescribePlayerSessions(ctx context.Context, params *DescribePlayerSessionsParams) (*DescribePlayerSessionsResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	resp := new(DescribePlayerSessionsResponse)
+	if err := c.do(ctx, http.MethodGet, "/v1/player-sessions", query, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// EndProcess calls EndProcessV1, POST /v1/process/end. Tell the agent the
+// process is ending.
+func (c *Client) EndProcess(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/v1/process/end", nil, nil, nil)
+}
+
+// GetGameServerSession calls GetGameServerSessionV1, GET
+// /v1/game-server-session. The game server session delivered by
+// OnStartGameServerSession.
+func (c *Client) GetGameServerSession(ctx context.Context) (*GameServerSession, error) {
+	resp := new(GameServerSession)
+	if err := c.do(ctx, http.MethodGet, "/v1/game-server-session", nil, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStatus calls GetStatusV1, GET /v1/status. Wrapper lifecycle state, ports
+// and counters.
+func (c *Client) GetStatus(ctx context.Context) (*WrapperStatus, error) {
+	resp := new(WrapperStatus)
+	if err := c.do(ctx, http.MethodGet, "/v1/status", nil, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Healthz calls Healthz, GET /healthz. Liveness probe.
+//
+// 200 unless the game reported itself unhealthy, the same state OnHealthCheck
+// and grpc.health.v1 report. Needs no token. Also served alone on -probe-addr.
+func (c *Client) Healthz(ctx context.Context) (*ProbeResponse, error) {
+	resp := new(ProbeResponse)
+	if err := c.do(ctx, http.MethodGet, "/healthz", nil, nil, resp, 503); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PushCustomData calls PushCustomDataV1, POST /v1/custom-data/push. Hand custom
+// counters to the automatic reporter.
+//
+// Returns at once. The wrapper reports to the agent only when the values
+// change, at most once per -custom-data-interval. A maxCustomCount of 0 means
+// the session's MaxPlayers. Answers 400 FailedPrecondition unless the wrapper
+// runs with -custom-data-source=push.
+func (c *Client) PushCustomData(ctx context.Context, body *CustomDataRequest) error {
+	return c.do(ctx, http.MethodPost, "/v1/custom-data/push", nil, body, nil)
+}
+
+// QueryCachedPlayerSessions calls QueryCachedPlayerSessionsV1, GET
+// /v1/player-sessions/cached. List player sessions from the wrapper's local
+// cache.
+//
+// Answered without calling the agent. The cache is updated by accept/remove
+// calls and game log events and reconciled with the agent every
+// -player-session-reconcile-interval.
+func (c *Client) QueryCachedPlayerSessions(ctx context.Context, params *QueryCachedPlayerSessionsParams) (*QueryCachedPlayerSessionsResponse, error) {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	resp := new(QueryCachedPlayerSessionsResponse)
+	if err := c.do(ctx, http.MethodGet, "/v1/player-sessions/cached", query, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Readyz calls Readyz, GET /readyz. Readiness probe.
+//
+// 200 while the game is healthy, listening (SERVER_READY or SESSION_ACTIVE) and
+// neither draining nor terminating. Needs no token. Also served alone on
+// -probe-addr.
+func (c *Client) Readyz(ctx context.Context) (*ProbeResponse, error) {
+	resp := new(ProbeResponse)
+	if err := c.do(ctx, http.MethodGet, "/readyz", nil, nil, resp, 503); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RemovePlayerSession calls RemovePlayerSessionV1, POST
+// /v1/player-sessions/remove. Remove a player session.
+func (c *Client) RemovePlayerSession(ctx context.Context, body *PlayerSessionRequest) error {
+	return c.do(ctx, http.MethodPost, "/v1/player-sessions/remove", nil, body, nil)
+}
+
+// RemovePlayerSessions calls BatchLogoutV1, POST
+// /v1/player-sessions/batch-remove. Remove up to 100 player sessions.
+func (c *Client) RemovePlayerSessions(ctx context.Context, body *BatchPlayerSessionRequest) (*BatchPlayerSessionResponse, error) {
+	resp := new(BatchPlayerSessionResponse)
+	if err := c.do(ctx, http.MethodPost, "/v1/player-sessions/batch-remove", nil, body, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReportCustomData calls ReportCustomDataV1, POST /v1/custom-data. Report
+// custom counters to the agent.
+func (c *Client) ReportCustomData(ctx context.Context, body *CustomDataRequest) error {
+	return c.do(ctx, http.MethodPost, "/v1/custom-data", nil, body, nil)
+}
+
+// SetHealthStatus calls SetHealthStatusV1, PUT /v1/health-status. Set the
+// health reported to OnHealthCheck.
+func (c *Client) SetHealthStatus(ctx context.Context, body *HealthStatusRequest) error {
+	return c.do(ctx, http.MethodPut, "/v1/health-status", nil, body, nil)
+}
+
+// StandaloneProcessTerminate calls StandaloneProcessTerminate, POST
+// /gse/standalone/process-terminate. Terminate the process as
+// OnProcessTerminate would.
+//
+// Only with -standalone.
+func (c *Client) StandaloneProcessTerminate(ctx context.Context, params *StandaloneProcessTerminateParams) error {
+	var query url.Values
+	if params != nil {
+		query = params.values()
+	}
+	return c.do(ctx, http.MethodPost, "/gse/standalone/process-terminate", query, nil, nil)
+}
+
+// StandaloneStartGameServerSession calls StandaloneStartGameServerSession, POST
+// /gse/standalone/start-game-server-session. Start a game server session as
+// OnStartGameServerSession would.
+//
+// Only with -standalone. Missing fields get local defaults: a generated
+// gameServerSessionId, 127.0.0.1 and the game port.
+func (c *Client) StandaloneStartGameServerSession(ctx context.Context, body *GameServerSession) (*GameServerSession, error) {
+	var reqBody interface{}
+	if body != nil {
+		reqBody = body
+	}
+	resp := new(GameServerSession)
+	if err := c.do(ctx, http.MethodPost, "/gse/standalone/start-game-server-session", nil, reqBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TerminateSession calls TerminateSessionV1, POST
+// /v1/game-server-session/terminate. Terminate the current game server session.
+func (c *Client) TerminateSession(ctx context.Context) error {
+	return c.do(ctx, http.MethodPost, "/v1/game-server-session/terminate", nil, nil, nil)
+}
+
+// UpdatePlayerSessionCreationPolicy calls UpdatePlayerSessionCreationPolicyV1,
+// PUT /v1/player-session-creation-policy. Set the player session creation
+// policy.
+//
+// Unless the wrapper runs with -auto-player-session-policy=false, DENY_ALL is
+// sent instead while the session is full or draining, and the requested policy
+// is restored afterwards.
+func (c *Client) UpdatePlayerSessionCreationPolicy(ctx context.Context, body *PlayerSessionCreationPolicyRequest) error {
+	return c.do(ctx, http.MethodPut, "/v1/player-session-creation-policy", nil, body, nil)
+}
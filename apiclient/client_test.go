@@ -0,0 +1,346 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// 客户端需要和 api/openapi.json 一致：每个方法调用的路由、参数和请求体字段都在 spec 里，
+// 类型的 json 字段和对应的 schema 一一对应
+
+type schemaNode struct {
+	Ref        string                 `json:"$ref"`
+	Properties map[string]*schemaNode `json:"properties"`
+	Items      *schemaNode            `json:"items"`
+}
+
+type specParameter struct {
+	Ref  string `json:"$ref"`
+	Name string `json:"name"`
+	In   string `json:"in"`
+}
+
+type specOperation struct {
+	Parameters  []*specParameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *schemaNode `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+type spec struct {
+	Paths      map[string]map[string]*specOperation `json:"paths"`
+	Components struct {
+		Parameters map[string]*specParameter `json:"parameters"`
+		Schemas    map[string]*schemaNode    `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadSpec(t *testing.T) *spec {
+	t.Helper()
+
+	b, err := ioutil.ReadFile("../api/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := new(spec)
+	if err := json.Unmarshal(b, s); err != nil {
+		t.Fatalf("openapi.json: %v", err)
+	}
+	return s
+}
+
+func (s *spec) resolve(node *schemaNode) *schemaNode {
+	for node != nil && node.Ref != "" {
+		node = s.Components.Schemas[strings.TrimPrefix(node.Ref, "#/components/schemas/")]
+	}
+	return node
+}
+
+// parameters returns the operation's parameters as "in name", lowercased.
+func (s *spec) parameters(op *specOperation) map[string]bool {
+	params := make(map[string]bool)
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			p = s.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+		}
+		params[p.In+" "+strings.ToLower(p.Name)] = true
+	}
+	return params
+}
+
+type recordedCall struct {
+	method  string
+	path    string
+	query   []string
+	headers []string
+	body    map[string]json.RawMessage
+}
+
+// recorder answers every request with an empty 200 and keeps what was sent.
+type recorder struct {
+	mu    sync.Mutex
+	calls []*recordedCall
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	call := &recordedCall{method: req.Method, path: req.URL.Path}
+	for key := range req.URL.Query() {
+		call.query = append(call.query, key)
+	}
+	for _, key := range []string{GrpcPortHeader, "Last-Event-ID"} {
+		if req.Header.Get(key) != "" {
+			call.headers = append(call.headers, key)
+		}
+	}
+	if b, _ := ioutil.ReadAll(req.Body); len(b) > 0 {
+		json.Unmarshal(b, &call.body)
+	}
+
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+
+	if req.URL.Path == "/gse/events" {
+		w.Header().Set("Content-Type", "text/event-stream")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("{}"))
+}
+
+func (r *recorder) take() []*recordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := r.calls
+	r.calls = nil
+	return calls
+}
+
+func TestClientMatchesSpec(t *testing.T) {
+	s := loadSpec(t)
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	c.Token = "token"
+	c.GrpcPort = "7000"
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"DescribePlayerSessions": func() error {
+			_, err := c.DescribePlayerSessions(ctx, &DescribePlayerSessionsParams{
+				GameServerSessionId: "gss", PlayerId: "p", PlayerSessionId: "psess-1",
+				PlayerSessionStatusFilter: "ACTIVE", NextToken: "n", Limit: 10,
+			})
+			return err
+		},
+		"QueryCachedPlayerSessions": func() error {
+			_, err := c.QueryCachedPlayerSessions(ctx, &QueryCachedPlayerSessionsParams{
+				PlayerSessionId: "psess-1", PlayerId: "p", Status: "ACTIVE",
+			})
+			return err
+		},
+		"AcceptPlayerSession": func() error {
+			return c.AcceptPlayerSession(ctx, &PlayerSessionRequest{PlayerSessionId: "psess-1"})
+		},
+		"RemovePlayerSession": func() error {
+			return c.RemovePlayerSession(ctx, &PlayerSessionRequest{PlayerSessionId: "psess-1"})
+		},
+		"AcceptPlayerSessions": func() error {
+			_, err := c.AcceptPlayerSessions(ctx, &BatchPlayerSessionRequest{PlayerSessionIds: []string{"psess-1"}})
+			return err
+		},
+		"RemovePlayerSessions": func() error {
+			_, err := c.RemovePlayerSessions(ctx, &BatchPlayerSessionRequest{PlayerSessionIds: []string{"psess-1"}})
+			return err
+		},
+		"UpdatePlayerSessionCreationPolicy": func() error {
+			return c.UpdatePlayerSessionCreationPolicy(ctx,
+				&PlayerSessionCreationPolicyRequest{NewPlayerSessionCreationPolicy: "ACCEPT_ALL"})
+		},
+		"GetGameServerSession": func() error {
+			_, err := c.GetGameServerSession(ctx)
+			return err
+		},
+		"GetStatus": func() error {
+			_, err := c.GetStatus(ctx)
+			return err
+		},
+		"TerminateSession": func() error { return c.TerminateSession(ctx) },
+		"EndProcess":       func() error { return c.EndProcess(ctx) },
+		"ReportCustomData": func() error {
+			return c.ReportCustomData(ctx, &CustomDataRequest{CurrentCustomCount: 1, MaxCustomCount: 2})
+		},
+		"PushCustomData": func() error {
+			return c.PushCustomData(ctx, &CustomDataRequest{CurrentCustomCount: 1, MaxCustomCount: 2})
+		},
+		"SetHealthStatus": func() error { return c.SetHealthStatus(ctx, &HealthStatusRequest{HealthStatus: true}) },
+		"StandaloneStartGameServerSession": func() error {
+			_, err := c.StandaloneStartGameServerSession(ctx, &GameServerSession{
+				GameServerSessionId: "gss", MaxPlayers: 4, GameProperties: []*GameProperty{{Key: "map", Value: "volcano"}},
+			})
+			return err
+		},
+		"StandaloneProcessTerminate": func() error {
+			return c.StandaloneProcessTerminate(ctx, &StandaloneProcessTerminateParams{TerminationTime: 1})
+		},
+		"Healthz": func() error {
+			_, err := c.Healthz(ctx)
+			return err
+		},
+		"Readyz": func() error {
+			_, err := c.Readyz(ctx)
+			return err
+		},
+		"StreamEvents": func() error {
+			_, err := c.StreamEvents(ctx, &StreamEventsParams{Types: []string{"player_connected"}, Replay: 1, LastEventId: 3},
+				func(*Event) error { return nil })
+			return err
+		},
+	}
+
+	// 新增的方法也要加到上面，否则这里失败
+	clientType := reflect.TypeOf(c)
+	for i := 0; i < clientType.NumMethod(); i++ {
+		if name := clientType.Method(i).Name; calls[name] == nil {
+			t.Errorf("Client.%s is not covered by this test", name)
+		}
+	}
+
+	names := make([]string, 0, len(calls))
+	for name := range calls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := calls[name](); err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		for _, call := range rec.take() {
+			checkCall(t, s, name, call)
+		}
+	}
+}
+
+func checkCall(t *testing.T, s *spec, name string, call *recordedCall) {
+	t.Helper()
+
+	op := s.Paths[call.path][strings.ToLower(call.method)]
+	if op == nil {
+		t.Errorf("%s: %s %s is not in openapi.json", name, call.method, call.path)
+		return
+	}
+
+	params := s.parameters(op)
+	for _, key := range call.query {
+		if !params["query "+strings.ToLower(key)] {
+			t.Errorf("%s: query parameter %s is not documented for %s %s", name, key, call.method, call.path)
+		}
+	}
+	for _, key := range call.headers {
+		if !params["header "+strings.ToLower(key)] {
+			t.Errorf("%s: header %s is not documented for %s %s", name, key, call.method, call.path)
+		}
+	}
+
+	if call.body == nil {
+		return
+	}
+	if op.RequestBody == nil {
+		t.Errorf("%s: %s %s takes no request body", name, call.method, call.path)
+		return
+	}
+	schema := s.resolve(op.RequestBody.Content["application/json"].Schema)
+	for key := range call.body {
+		if schema == nil || schema.Properties[key] == nil {
+			t.Errorf("%s: request body field %s is not documented for %s %s", name, key, call.method, call.path)
+		}
+	}
+}
+
+func TestTypesMatchSchemas(t *testing.T) {
+	s := loadSpec(t)
+
+	types := map[string]interface{}{
+		"PlayerSessionV1":                    PlayerSession{},
+		"DescribePlayerSessionsResponseV1":   DescribePlayerSessionsResponse{},
+		"CachedPlayerSession":                CachedPlayerSession{},
+		"QueryCachedPlayerSessionsResponse":  QueryCachedPlayerSessionsResponse{},
+		"GameServerSession":                  GameServerSession{},
+		"WrapperStatus":                      WrapperStatus{},
+		"PlayerSessionResult":                PlayerSessionResult{},
+		"BatchPlayerSessionResponse":         BatchPlayerSessionResponse{},
+		"ProbeResponse":                      ProbeResponse{},
+		"Event":                              Event{},
+		"PlayerSessionRequest":               PlayerSessionRequest{},
+		"BatchPlayerSessionRequest":          BatchPlayerSessionRequest{},
+		"PlayerSessionCreationPolicyRequest": PlayerSessionCreationPolicyRequest{},
+		"CustomDataRequest":                  CustomDataRequest{},
+		"HealthStatusRequest":                HealthStatusRequest{},
+	}
+	for name, v := range types {
+		schema := s.Components.Schemas[name]
+		if schema == nil {
+			t.Errorf("schema %s is not in openapi.json", name)
+			continue
+		}
+		checkFields(t, s, name, reflect.TypeOf(v), schema)
+	}
+
+	errorSchema := s.Components.Schemas["ErrorResponse"].Properties["error"]
+	checkFields(t, s, "ErrorResponse.error", reflect.TypeOf(Error{}), errorSchema)
+}
+
+// checkFields compares the json names of typ's fields with the properties
+// of schema, and recurses into fields whose schema is an object.
+func checkFields(t *testing.T, s *spec, name string, typ reflect.Type, schema *schemaNode) {
+	t.Helper()
+
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	schema = s.resolve(schema)
+	for schema != nil && schema.Items != nil {
+		schema = s.resolve(schema.Items)
+	}
+	if typ.Kind() != reflect.Struct || schema == nil || schema.Properties == nil {
+		return
+	}
+
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		tag := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fields[tag] = typ.Field(i).Type
+	}
+
+	for field, fieldType := range fields {
+		property := schema.Properties[field]
+		if property == nil {
+			t.Errorf("%s: %s.%s is not in the schema", name, typ.Name(), field)
+			continue
+		}
+		checkFields(t, s, name+"."+field, fieldType, property)
+	}
+	for property := range schema.Properties {
+		if _, ok := fields[property]; !ok {
+			t.Errorf("%s: property %s has no field in %s", name, property, typ.Name())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package apiclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is the Event schema, one wrapper lifecycle event.
+type Event struct {
+	Seq                 uint64    `json:"seq"`
+	Type                string    `json:"type"`
+	Time                time.Time `json:"time"`
+	GrpcPort            int       `json:"grpcPort,omitempty"`
+	GameServerSessionId string    `json:"gameServerSessionId,omitempty"`
+	PlayerId            string    `json:"playerId,omitempty"`
+	PlayerSessionId     string    `json:"playerSessionId,omitempty"`
+	Policy              string    `json:"policy,omitempty"`
+	Healthy             *bool     `json:"healthy,omitempty"`
+	TerminationTime     int64     `json:"terminationTime,omitempty"`
+}
+
+type StreamEventsParams struct {
+	// Types limits the stream to these event types, all when empty.
+	Types []string
+	// Replay is how many kept events the stream starts with; negative
+	// replays every kept event.
+	Replay int
+	// LastEventId resumes the stream after this seq instead of replaying.
+	LastEventId uint64
+}
+
+// StreamEvents calls StreamEvents, GET /gse/events, and hands each event to
+// fn until ctx is done, the server closes the stream or fn returns an error.
+// It returns the seq of the last event handed to fn, for resuming with
+// LastEventId.
+func (c *Client) StreamEvents(ctx context.Context, params *StreamEventsParams, fn func(*Event) error) (uint64, error) {
+	if params == nil {
+		params = &StreamEventsParams{}
+	}
+
+	q := url.Values{}
+	if len(params.Types) > 0 {
+		q.Set("types", strings.Join(params.Types, ","))
+	}
+	if params.Replay >= 0 {
+		q.Set("replay", strconv.Itoa(params.Replay))
+	}
+
+	path := "/gse/events"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return params.LastEventId, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if params.LastEventId > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(params.LastEventId, 10))
+	}
+
+	// 事件流不会结束，不能用带超时的 client
+	client := *c.HTTPClient
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return params.LastEventId, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		envelope := struct {
+			Error *Error `json:"error"`
+		}{}
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil || envelope.Error == nil {
+			return params.LastEventId, &Error{HTTPStatus: resp.StatusCode, Code: "Unknown", Message: resp.Status}
+		}
+		envelope.Error.HTTPStatus = resp.StatusCode
+		return params.LastEventId, envelope.Error
+	}
+
+	last := params.LastEventId
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		case line == "" && data.Len() > 0:
+			ev := new(Event)
+			err := json.Unmarshal([]byte(data.String()), ev)
+			data.Reset()
+			if err != nil {
+				return last, err
+			}
+			if err := fn(ev); err != nil {
+				return ev.Seq, err
+			}
+			last = ev.Seq
+		}
+		// id:、event: 和注释行都不需要，seq 和 type 在 data 里
+	}
+	if ctx.Err() != nil {
+		return last, ctx.Err()
+	}
+	return last, scanner.Err()
+}
@@ -0,0 +1,630 @@
+// Command gen generates the types and Client methods of package apiclient
+// from api/openapi.json:
+//
+//	go run ./gen -o client_gen.go ../api/openapi.json
+//
+// Every operation with x-go-name becomes a Client method of that name. Its
+// query parameters become a <name>Params struct, its JSON request body the
+// body argument and its JSON response the result. The schemas those use
+// become types, named by their x-go-name when they have one. The grpc port
+// header is left to Client.GrpcPort, and streaming operations are written by
+// hand.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	schemaPrefix    = "#/components/schemas/"
+	parameterPrefix = "#/components/parameters/"
+
+	// grpcPortParameter 由 Client.GrpcPort 统一设置，不进方法参数
+	grpcPortParameter = "GrpcPort"
+)
+
+// ordered keeps the keys of a JSON object in document order, so generated
+// fields follow the spec.
+type ordered struct {
+	keys []string
+	raw  map[string]json.RawMessage
+}
+
+func (o *ordered) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	o.raw = make(map[string]json.RawMessage)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		o.keys = append(o.keys, key)
+		o.raw[key] = value
+	}
+	return nil
+}
+
+type schema struct {
+	Ref         string   `json:"$ref"`
+	Type        string   `json:"type"`
+	Format      string   `json:"format"`
+	Description string   `json:"description"`
+	Properties  ordered  `json:"properties"`
+	Required    []string `json:"required"`
+	Items       *schema  `json:"items"`
+	GoName      string   `json:"x-go-name"`
+
+	properties map[string]*schema
+}
+
+func (s *schema) property(name string) (*schema, error) {
+	if s.properties == nil {
+		s.properties = make(map[string]*schema)
+	}
+	if p, ok := s.properties[name]; ok {
+		return p, nil
+	}
+
+	p := new(schema)
+	if err := json.Unmarshal(s.Properties.raw[name], p); err != nil {
+		return nil, fmt.Errorf("property %s: %v", name, err)
+	}
+	s.properties[name] = p
+	return p, nil
+}
+
+func (s *schema) required(name string) bool {
+	for _, r := range s.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+type parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *schema `json:"schema"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type response struct {
+	Ref     string                `json:"$ref"`
+	Content map[string]*mediaType `json:"content"`
+}
+
+type operation struct {
+	OperationId string       `json:"operationId"`
+	Summary     string       `json:"summary"`
+	Description string       `json:"description"`
+	GoName      string       `json:"x-go-name"`
+	Parameters  []*parameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool                  `json:"required"`
+		Content  map[string]*mediaType `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]*response `json:"responses"`
+
+	method string
+	path   string
+}
+
+type spec struct {
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas    map[string]*schema    `json:"schemas"`
+		Parameters map[string]*parameter `json:"parameters"`
+		Responses  map[string]*response  `json:"responses"`
+	} `json:"components"`
+}
+
+type generator struct {
+	spec *spec
+
+	// types 是要生成的 struct，按 Go 名字；specNames 是它们在 spec 里的名字
+	types     map[string]*schema
+	specNames map[string]string
+	params    map[string]*operation
+
+	usesStrconv bool
+	usesTime    bool
+}
+
+// Generate returns the formatted Go source for the spec in b.
+func Generate(b []byte) ([]byte, error) {
+	s := new(spec)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		spec:      s,
+		types:     make(map[string]*schema),
+		specNames: make(map[string]string),
+		params:    make(map[string]*operation),
+	}
+	ops, err := g.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	var methods bytes.Buffer
+	for _, op := range ops {
+		if err := g.method(&methods, op); err != nil {
+			return nil, fmt.Errorf("%s: %v", op.OperationId, err)
+		}
+	}
+
+	var types bytes.Buffer
+	if err := g.structs(&types); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by apiclient/gen from api/openapi.json. DO NOT EDIT.\n\n")
+	out.WriteString("package apiclient\n\nimport (\n\t\"context\"\n\t\"net/http\"\n")
+	if len(g.params) > 0 {
+		out.WriteString("\t\"net/url\"\n")
+	}
+	if g.usesStrconv {
+		out.WriteString("\t\"strconv\"\n")
+	}
+	if g.usesTime {
+		out.WriteString("\t\"time\"\n")
+	}
+	out.WriteString(")\n\n")
+	out.Write(types.Bytes())
+	out.Write(methods.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+// operations returns the operations with x-go-name, sorted by it.
+func (g *generator) operations() ([]*operation, error) {
+	var ops []*operation
+	names := make(map[string]bool)
+	for path, item := range g.spec.Paths {
+		for method, op := range item {
+			if op.GoName == "" {
+				continue
+			}
+			if names[op.GoName] {
+				return nil, fmt.Errorf("x-go-name %s is used twice", op.GoName)
+			}
+			names[op.GoName] = true
+			op.method, op.path = strings.ToUpper(method), path
+			ops = append(ops, op)
+		}
+	}
+
+	sort.Slice(ops, func(i, j int) bool { return ops[i].GoName < ops[j].GoName })
+	return ops, nil
+}
+
+func (g *generator) resolveSchema(s *schema) (string, *schema, error) {
+	if s.Ref == "" {
+		return "", s, nil
+	}
+	if !strings.HasPrefix(s.Ref, schemaPrefix) {
+		return "", nil, fmt.Errorf("unsupported $ref %s", s.Ref)
+	}
+	name := strings.TrimPrefix(s.Ref, schemaPrefix)
+	resolved := g.spec.Components.Schemas[name]
+	if resolved == nil {
+		return "", nil, fmt.Errorf("unknown schema %s", name)
+	}
+	return name, resolved, nil
+}
+
+// structType registers the object schema s as a type and returns its name.
+func (g *generator) structType(specName string, s *schema) (string, error) {
+	name := s.GoName
+	if name == "" {
+		name = specName
+	}
+	if name == "" {
+		return "", fmt.Errorf("inline object needs x-go-name")
+	}
+
+	if existing, ok := g.types[name]; ok {
+		if existing != s {
+			return "", fmt.Errorf("two schemas are named %s", name)
+		}
+		return name, nil
+	}
+	g.types[name] = s
+	g.specNames[name] = specName
+	for _, property := range s.Properties.keys {
+		p, err := s.property(property)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", name, err)
+		}
+		if _, err := g.goType(p); err != nil {
+			return "", fmt.Errorf("%s.%s: %v", name, property, err)
+		}
+	}
+	return name, nil
+}
+
+// goType returns the Go type of a field with schema s.
+func (g *generator) goType(s *schema) (string, error) {
+	specName, s, err := g.resolveSchema(s)
+	if err != nil {
+		return "", err
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "int64":
+			return "int64", nil
+		case "date-time":
+			g.usesTime = true
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int32", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		elem, err := g.goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case "object":
+		name, err := g.structType(specName, s)
+		if err != nil {
+			return "", err
+		}
+		return "*" + name, nil
+	}
+	return "", fmt.Errorf("unsupported schema type %q", s.Type)
+}
+
+func (g *generator) structs(w *bytes.Buffer) error {
+	names := make([]string, 0, len(g.types))
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := g.types[name]
+		if specName := g.specNames[name]; specName != "" {
+			comment(w, "", fmt.Sprintf("%s is the %s schema. %s", name, specName, s.Description))
+		} else if s.Description != "" {
+			comment(w, "", name+" is "+s.Description+".")
+		}
+		fmt.Fprintf(w, "type %s struct {\n", name)
+		for _, property := range s.Properties.keys {
+			p, err := s.property(property)
+			if err != nil {
+				return err
+			}
+			typ, err := g.goType(p)
+			if err != nil {
+				return err
+			}
+
+			tag := property
+			if !s.required(property) {
+				tag += ",omitempty"
+			}
+			if _, resolved, _ := g.resolveSchema(p); resolved.Type == "string" && resolved.Format == "int64" {
+				// proto3 JSON 把 int64 编码成字符串
+				tag += ",string"
+			}
+			comment(w, "\t", p.Description)
+			fmt.Fprintf(w, "\t%s %s `json:\"%s\"`\n", exported(property), typ, tag)
+		}
+		w.WriteString("}\n\n")
+	}
+
+	ops := make([]string, 0, len(g.params))
+	for name := range g.params {
+		ops = append(ops, name)
+	}
+	sort.Strings(ops)
+	for _, name := range ops {
+		if err := g.paramsStruct(w, name, g.params[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) parameters(op *operation) ([]*parameter, error) {
+	var query []*parameter
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			name := strings.TrimPrefix(p.Ref, parameterPrefix)
+			if name == grpcPortParameter {
+				continue
+			}
+			if p = g.spec.Components.Parameters[name]; p == nil {
+				return nil, fmt.Errorf("unknown parameter %s", name)
+			}
+		}
+		if p.In != "query" {
+			return nil, fmt.Errorf("unsupported %s parameter %s", p.In, p.Name)
+		}
+		query = append(query, p)
+	}
+	return query, nil
+}
+
+func (g *generator) paramsStruct(w *bytes.Buffer, name string, op *operation) error {
+	query, err := g.parameters(op)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "// %sParams are the query parameters of %s.\n", name, name)
+	fmt.Fprintf(w, "type %sParams struct {\n", name)
+	for _, p := range query {
+		typ, err := g.goType(p.Schema)
+		if err != nil {
+			return fmt.Errorf("%s: %v", p.Name, err)
+		}
+		comment(w, "\t", p.Description)
+		fmt.Fprintf(w, "\t%s %s\n", exported(p.Name), typ)
+	}
+	w.WriteString("}\n\n")
+
+	fmt.Fprintf(w, "func (p *%sParams) values() url.Values {\n\tquery := url.Values{}\n", name)
+	for _, p := range query {
+		field := "p." + exported(p.Name)
+		var value, zero string
+		switch typ, _ := g.goType(p.Schema); typ {
+		case "string":
+			value, zero = field, `""`
+		case "int32":
+			value, zero = "strconv.FormatInt(int64("+field+"), 10)", "0"
+		case "int64":
+			value, zero = "strconv.FormatInt("+field+", 10)", "0"
+		case "bool":
+			value, zero = "strconv.FormatBool("+field+")", "false"
+		default:
+			return fmt.Errorf("%s: unsupported query parameter type %s", p.Name, typ)
+		}
+		if value != field {
+			g.usesStrconv = true
+		}
+
+		if p.Required {
+			fmt.Fprintf(w, "\tquery.Set(%q, %s)\n", p.Name, value)
+		} else {
+			fmt.Fprintf(w, "\tif %s != %s {\n\t\tquery.Set(%q, %s)\n\t}\n", field, zero, p.Name, value)
+		}
+	}
+	w.WriteString("\treturn query\n}\n\n")
+	return nil
+}
+
+// result returns the type name of the JSON response, empty when the
+// operation answers with no content or an empty object, and the other
+// statuses that carry the same schema.
+func (g *generator) result(op *operation) (string, []int, error) {
+	var result *schema
+	var resultRef string
+	for code, resp := range op.Responses {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if resp.Content == nil {
+			continue
+		}
+		media := resp.Content["application/json"]
+		if media == nil || media.Schema == nil {
+			return "", nil, fmt.Errorf("response %s is not JSON", code)
+		}
+		result, resultRef = media.Schema, media.Schema.Ref
+	}
+	if result == nil {
+		return "", nil, nil
+	}
+
+	specName, resolved, err := g.resolveSchema(result)
+	if err != nil {
+		return "", nil, err
+	}
+	if resolved.Type != "object" {
+		return "", nil, fmt.Errorf("response is a %s, not an object", resolved.Type)
+	}
+	if len(resolved.Properties.keys) == 0 {
+		return "", nil, nil
+	}
+	name, err := g.structType(specName, resolved)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// 例如探针的 503 也是 ProbeResponse，不当作错误
+	var also []int
+	for code, resp := range op.Responses {
+		if strings.HasPrefix(code, "2") || resp.Content == nil {
+			continue
+		}
+		if media := resp.Content["application/json"]; media != nil && media.Schema != nil && media.Schema.Ref == resultRef {
+			status, err := strconv.Atoi(code)
+			if err != nil {
+				return "", nil, fmt.Errorf("response %s: %v", code, err)
+			}
+			also = append(also, status)
+		}
+	}
+	sort.Ints(also)
+	return name, also, nil
+}
+
+func (g *generator) method(w *bytes.Buffer, op *operation) error {
+	query, err := g.parameters(op)
+	if err != nil {
+		return err
+	}
+	result, also, err := g.result(op)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"ctx context.Context"}
+	queryArg, bodyArg := "nil", "nil"
+	if len(query) > 0 {
+		g.params[op.GoName] = op
+		args = append(args, "params *"+op.GoName+"Params")
+		queryArg = "query"
+	}
+	optionalBody := false
+	if op.RequestBody != nil {
+		media := op.RequestBody.Content["application/json"]
+		if media == nil || media.Schema == nil {
+			return fmt.Errorf("request body is not JSON")
+		}
+		typ, err := g.goType(media.Schema)
+		if err != nil {
+			return fmt.Errorf("request body: %v", err)
+		}
+		args = append(args, "body "+typ)
+		bodyArg, optionalBody = "body", !op.RequestBody.Required
+	}
+
+	comment(w, "", fmt.Sprintf("%s calls %s, %s %s. %s.", op.GoName, op.OperationId, op.method, op.path, op.Summary))
+	if op.Description != "" {
+		w.WriteString("//\n")
+		comment(w, "", op.Description)
+	}
+	returns := "error"
+	if result != "" {
+		returns = "(*" + result + ", error)"
+	}
+	fmt.Fprintf(w, "func (c *Client) %s(%s) %s {\n", op.GoName, strings.Join(args, ", "), returns)
+
+	if queryArg != "nil" {
+		w.WriteString("\tvar query url.Values\n\tif params != nil {\n\t\tquery = params.values()\n\t}\n")
+	}
+	if optionalBody {
+		// nil 指针放进 interface{} 不是 nil，可选的 body 要单独判断
+		w.WriteString("\tvar reqBody interface{}\n\tif body != nil {\n\t\treqBody = body\n\t}\n")
+		bodyArg = "reqBody"
+	}
+
+	call := fmt.Sprintf("c.do(ctx, %s, %q, %s, %s", httpMethod(op.method), op.path, queryArg, bodyArg)
+	if result == "" {
+		fmt.Fprintf(w, "\treturn %s, nil)\n}\n\n", call)
+		return nil
+	}
+
+	call += ", resp"
+	for _, status := range also {
+		call += ", " + strconv.Itoa(status)
+	}
+	fmt.Fprintf(w, "\tresp := new(%s)\n\tif err := %s); err != nil {\n\t\treturn nil, err\n\t}\n\treturn resp, nil\n}\n\n", result, call)
+	return nil
+}
+
+func httpMethod(method string) string {
+	return "http.Method" + method[:1] + strings.ToLower(method[1:])
+}
+
+// exported turns a JSON name such as playerSessionId into PlayerSessionId.
+func exported(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '-' || r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// comment writes text as a // comment wrapped at about 80 columns.
+func comment(w *bytes.Buffer, indent, text string) {
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(indent)*4+len(line)+len(word) > 76 {
+			fmt.Fprintf(w, "%s// %s\n", indent, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		fmt.Fprintf(w, "%s// %s\n", indent, line)
+	}
+}
+
+func main() {
+	out := flag.String("o", "", "the file to write, stdout when empty")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: gen [-o file] openapi.json")
+		os.Exit(2)
+	}
+
+	b, err := ioutil.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := Generate(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
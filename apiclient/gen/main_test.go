@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// client_gen.go 要和 openapi.json 同步，改了 spec 之后跑 go generate
+func TestClientGenIsUpToDate(t *testing.T) {
+	spec, err := ioutil.ReadFile("../../api/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := Generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile("../client_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("apiclient/client_gen.go is stale; run go generate ./apiclient")
+	}
+}
+
+func TestGenerateRejectsUnsupportedOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{
+			name: "path parameter",
+			spec: `{"paths": {"/v1/x/{id}": {"get": {"operationId": "X", "x-go-name": "X",
+				"parameters": [{"name": "id", "in": "path", "schema": {"type": "string"}}],
+				"responses": {"204": {"description": "done"}}}}}}`,
+			want: "unsupported path parameter id",
+		},
+		{
+			name: "streaming response",
+			spec: `{"paths": {"/v1/x": {"get": {"operationId": "X", "x-go-name": "X",
+				"responses": {"200": {"content": {"text/event-stream": {"schema": {"type": "string"}}}}}}}}}`,
+			want: "response 200 is not JSON",
+		},
+		{
+			name: "inline object without x-go-name",
+			spec: `{"paths": {"/v1/x": {"get": {"operationId": "X", "x-go-name": "X",
+				"responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/X"}}}}}}}},
+				"components": {"schemas": {"X": {"type": "object", "properties": {
+					"inner": {"type": "object", "properties": {"a": {"type": "string"}}}}}}}}`,
+			want: "inline object needs x-go-name",
+		},
+		{
+			name: "duplicate x-go-name",
+			spec: `{"paths": {
+				"/v1/x": {"post": {"operationId": "X", "x-go-name": "X", "responses": {"204": {"description": "done"}}}},
+				"/v1/y": {"post": {"operationId": "Y", "x-go-name": "X", "responses": {"204": {"description": "done"}}}}}}`,
+			want: "x-go-name X is used twice",
+		},
+	}
+	for _, tt := range tests {
+		if _, err := Generate([]byte(tt.spec)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
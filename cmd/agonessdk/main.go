@@ -0,0 +1,136 @@
+// agonessdk is a local stand-in for the Agones SDK sidecar's REST API: it
+// keeps one GameServer in memory, prints every call and streams changes on
+// /watch/gameserver, so -backend=agones can run without a cluster.
+//
+//	agonessdk -addr 127.0.0.1:9358
+//	curl -X POST 127.0.0.1:9358/allocate -d '{}'
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"supertuxkart/agones"
+	"sync"
+)
+
+type sidecar struct {
+	mu       sync.Mutex
+	gs       agones.GameServer
+	players  map[string]bool
+	watchers map[chan agones.GameServer]bool
+}
+
+func (s *sidecar) setState(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.gs.Status.State == state {
+		return
+	}
+	log.Printf("state %s -> %s", s.gs.Status.State, state)
+	s.gs.Status.State = state
+	for ch := range s.watchers {
+		select {
+		case ch <- s.gs:
+		default:
+		}
+	}
+}
+
+func (s *sidecar) post(path string, fn func(body map[string]string) interface{}) {
+	http.HandleFunc(path, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		body := map[string]string{}
+		json.NewDecoder(req.Body).Decode(&body)
+		if path != "/health" {
+			log.Printf("%s %v", path, body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(fn(body))
+	})
+}
+
+func (s *sidecar) player(connected bool) func(map[string]string) interface{} {
+	return func(body map[string]string) interface{} {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		changed := s.players[body["playerID"]] != connected
+		if connected {
+			s.players[body["playerID"]] = true
+		} else {
+			delete(s.players, body["playerID"])
+		}
+		return map[string]bool{"bool": changed}
+	}
+}
+
+func (s *sidecar) watch(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan agones.GameServer, 16)
+	s.mu.Lock()
+	s.watchers[ch] = true
+	ch <- s.gs
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case gs := <-ch:
+			enc.Encode(map[string]agones.GameServer{"result": gs})
+			flusher.Flush()
+		}
+	}
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:"+agones.DefaultPort, "the address to listen on")
+	name := flag.String("name", "stk-local", "the GameServer name")
+	address := flag.String("address", "127.0.0.1", "the GameServer address")
+	flag.Parse()
+
+	s := &sidecar{
+		gs: agones.GameServer{
+			ObjectMeta: agones.ObjectMeta{Name: *name, Namespace: "default"},
+			Status:     agones.Status{State: agones.StateScheduled, Address: *address},
+		},
+		players:  make(map[string]bool),
+		watchers: make(map[chan agones.GameServer]bool),
+	}
+
+	empty := map[string]interface{}{}
+	s.post("/ready", func(map[string]string) interface{} { s.setState(agones.StateReady); return empty })
+	s.post("/allocate", func(map[string]string) interface{} { s.setState(agones.StateAllocated); return empty })
+	s.post("/shutdown", func(map[string]string) interface{} { s.setState(agones.StateShutdown); return empty })
+	s.post("/health", func(map[string]string) interface{} { return empty })
+	s.post("/alpha/player/connect", s.player(true))
+	s.post("/alpha/player/disconnect", s.player(false))
+	http.HandleFunc("/gameserver", func(w http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		json.NewEncoder(w).Encode(s.gs)
+	})
+	http.HandleFunc("/watch/gameserver", s.watch)
+
+	log.Printf("agonessdk listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
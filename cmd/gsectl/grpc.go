@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"supertuxkart/apiclient"
+	"supertuxkart/grpcsdk"
+)
+
+// 回调服务只监听 127.0.0.1，gsectl 要和 wrapper 在同一台机器上
+
+func (c *gsectl) dialGrpc(ctx context.Context) (*grpc.ClientConn, error) {
+	port, err := c.resolveGrpcPort(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, fmt.Sprintf("127.0.0.1:%d", port), grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, fmt.Errorf("dial grpc port %d: %w", port, err)
+	}
+	return conn, nil
+}
+
+func terminateByGrpc(ctx context.Context, c *gsectl, terminationTime int64) error {
+	conn, err := c.dialGrpc(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = grpcsdk.NewGameServerGrpcSdkServiceClient(conn).OnProcessTerminate(ctx,
+		&grpcsdk.ProcessTerminateRequest{TerminationTime: terminationTime})
+	return err
+}
+
+func terminateByHttp(ctx context.Context, c *gsectl, terminationTime int64) error {
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	return c.client.StandaloneProcessTerminate(ctx, &apiclient.StandaloneProcessTerminateParams{TerminationTime: terminationTime})
+}
+
+type healthReport struct {
+	Grpc    string                   `json:"grpc"`
+	Healthz *apiclient.ProbeResponse `json:"healthz"`
+	Readyz  *apiclient.ProbeResponse `json:"readyz"`
+}
+
+// showHealth prints grpc.health.v1 next to the /healthz and /readyz probes.
+func showHealth(ctx context.Context, c *gsectl) error {
+	report := &healthReport{}
+
+	conn, err := c.dialGrpc(ctx)
+	if err != nil {
+		report.Grpc = err.Error()
+	} else {
+		defer conn.Close()
+		callCtx, cancel := c.call(ctx)
+		resp, err := healthpb.NewHealthClient(conn).Check(callCtx, &healthpb.HealthCheckRequest{})
+		cancel()
+		if err != nil {
+			report.Grpc = err.Error()
+		} else {
+			report.Grpc = resp.Status.String()
+		}
+	}
+
+	callCtx, cancel := c.call(ctx)
+	defer cancel()
+	healthz, err := c.client.Healthz(callCtx)
+	if err != nil {
+		return err
+	}
+	readyz, err := c.client.Readyz(callCtx)
+	if err != nil {
+		return err
+	}
+	report.Healthz, report.Readyz = healthz, readyz
+
+	return c.print(report, func(t *table) {
+		t.row("CHECK", "STATUS", "REASON")
+		t.row("grpc.health.v1", report.Grpc, "")
+		t.row("/healthz", healthz.Status, healthz.Reason)
+		t.row("/readyz", readyz.Status, readyz.Reason)
+	})
+}
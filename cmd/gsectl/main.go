@@ -0,0 +1,416 @@
+// gsectl talks to a running wrapper's HTTP and gRPC APIs, for operators
+// debugging a game server:
+//
+//	gsectl -addr http://127.0.0.1:8080 status
+//	gsectl players -status ACTIVE -all
+//	gsectl -o json session
+//	gsectl events -types player_join,player_leave
+//
+// Inside the game's environment GSE_HTTP_PORT, GSE_HTTP_TOKEN and
+// GSE_GRPC_PORT are picked up, so no flags are needed there.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"supertuxkart/apiclient"
+	"syscall"
+	"time"
+)
+
+type command struct {
+	usage string
+	help  string
+	run   func(ctx context.Context, ctl *gsectl, args []string) error
+}
+
+var commands map[string]*command
+
+// init 里赋值，否则 newFlagSet 引用 commands 会形成初始化循环
+func init() {
+	commands = map[string]*command{
+		"status":      {"status", "show the wrapper status", runStatus},
+		"session":     {"session", "show the current game server session", runSession},
+		"players":     {"players [flags]", "list player sessions, filtered by status, player or id; see players -h", runPlayers},
+		"accept":      {"accept PLAYER_SESSION_ID...", "accept player sessions", runAccept},
+		"remove":      {"remove PLAYER_SESSION_ID...", "remove player sessions", runRemove},
+		"policy":      {"policy ACCEPT_ALL|DENY_ALL", "set the player session creation policy", runPolicy},
+		"custom-data": {"custom-data [-push] CURRENT MAX", "report custom counters", runCustomData},
+		"health":      {"health [on|off]", "set the process health, or show grpc.health.v1 and the probes", runHealth},
+		"terminate":   {"terminate [-in DURATION] [-via grpc|http]", "simulate the agent's OnProcessTerminate", runTerminate},
+		"events":      {"events [-types T,...] [-replay N]", "tail the event stream until interrupted", runEvents},
+	}
+}
+
+// gsectl holds the global flags every command shares.
+type gsectl struct {
+	client   *apiclient.Client
+	output   string
+	grpcPort string
+	timeout  time.Duration
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "usage: gsectl [flags] COMMAND [args]\n\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	width := 0
+	for name, cmd := range commands {
+		names = append(names, name)
+		if len(cmd.usage) > width {
+			width = len(cmd.usage)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-*s  %s\n", width, commands[name].usage, commands[name].help)
+	}
+	fmt.Fprintf(out, "\nflags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	defaultAddr := ""
+	if port := os.Getenv(apiclient.PortEnv); port != "" {
+		defaultAddr = "http://127.0.0.1:" + port
+	}
+
+	addr := flag.String("addr", defaultAddr, "the wrapper's http address, from "+apiclient.PortEnv+" by default")
+	token := flag.String("token", os.Getenv(apiclient.TokenEnv), "the bearer token, from "+apiclient.TokenEnv+" by default")
+	grpcPort := flag.String("grpc-port", os.Getenv(apiclient.GrpcPortEnv),
+		"the game server process to talk to, from "+apiclient.GrpcPortEnv+" by default")
+	output := flag.String("o", "table", "output format, table or json")
+	timeout := flag.Duration("timeout", 10*time.Second, "the time limit of each call; events ignores it")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "gsectl: unknown command %q\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+	if *addr == "" {
+		fatal(fmt.Errorf("-addr is required when %s is not set", apiclient.PortEnv))
+	}
+	if *output != "table" && *output != "json" {
+		fatal(fmt.Errorf("unknown output %q, expect table or json", *output))
+	}
+
+	client := apiclient.NewClient(strings.TrimSuffix(*addr, "/"))
+	client.Token = *token
+	client.GrpcPort = *grpcPort
+	ctl := &gsectl{client: client, output: *output, grpcPort: *grpcPort, timeout: *timeout}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := cmd.run(ctx, ctl, flag.Args()[1:]); err != nil && !errors.Is(err, context.Canceled) {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "gsectl: %v\n", err)
+	os.Exit(1)
+}
+
+func (c *gsectl) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// resolveGrpcPort returns -grpc-port, or asks the wrapper for the port of
+// its first process.
+func (c *gsectl) resolveGrpcPort(ctx context.Context) (int, error) {
+	if c.grpcPort != "" {
+		return strconv.Atoi(c.grpcPort)
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	st, err := c.client.GetStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if st.GrpcPort == 0 {
+		return 0, errors.New("the wrapper has not announced a grpc port yet")
+	}
+	return int(st.GrpcPort), nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: gsectl %s\n", commands[name].usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func runStatus(ctx context.Context, c *gsectl, args []string) error {
+	newFlagSet("status").Parse(args)
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	st, err := c.client.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+	return c.print(st, func(t *table) { printStatus(t, st) })
+}
+
+func runSession(ctx context.Context, c *gsectl, args []string) error {
+	newFlagSet("session").Parse(args)
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	gameServerSession, err := c.client.GetGameServerSession(ctx)
+	if err != nil {
+		return err
+	}
+	return c.print(gameServerSession, func(t *table) { printSession(t, gameServerSession) })
+}
+
+func runPlayers(ctx context.Context, c *gsectl, args []string) error {
+	fs := newFlagSet("players")
+	statusFilter := fs.String("status", "", "RESERVED, ACTIVE, COMPLETED or TIMEDOUT")
+	playerId := fs.String("player-id", "", "only this player's sessions")
+	playerSessionId := fs.String("player-session-id", "", "only this player session")
+	limit := fs.Int("limit", 0, "page size, the agent's default when 0")
+	all := fs.Bool("all", false, "follow nextToken through every page")
+	cached := fs.Bool("cached", false, "read the wrapper's cache instead of asking the agent; shows who is connected")
+	fs.Parse(args)
+
+	if *cached {
+		ctx, cancel := c.call(ctx)
+		defer cancel()
+		resp, err := c.client.QueryCachedPlayerSessions(ctx, &apiclient.QueryCachedPlayerSessionsParams{
+			PlayerSessionId: *playerSessionId,
+			PlayerId:        *playerId,
+			Status:          *statusFilter,
+		})
+		if err != nil {
+			return err
+		}
+		return c.print(resp, func(t *table) { printCachedPlayerSessions(t, resp.PlayerSessions) })
+	}
+
+	params := &apiclient.DescribePlayerSessionsParams{
+		PlayerId:                  *playerId,
+		PlayerSessionId:           *playerSessionId,
+		PlayerSessionStatusFilter: *statusFilter,
+		Limit:                     int32(*limit),
+	}
+	result := &apiclient.DescribePlayerSessionsResponse{}
+	for {
+		callCtx, cancel := c.call(ctx)
+		resp, err := c.client.DescribePlayerSessions(callCtx, params)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		result.PlayerSessions = append(result.PlayerSessions, resp.PlayerSessions...)
+		result.NextToken = resp.NextToken
+		if !*all || resp.NextToken == "" {
+			break
+		}
+		params.NextToken = resp.NextToken
+	}
+	return c.print(result, func(t *table) {
+		printPlayerSessions(t, result.PlayerSessions)
+		if result.NextToken != "" {
+			t.note("more sessions, nextToken %s; use -all to list them", result.NextToken)
+		}
+	})
+}
+
+func runAccept(ctx context.Context, c *gsectl, args []string) error {
+	return batchPlayerSessions(ctx, c, "accept", args, c.client.AcceptPlayerSession, c.client.AcceptPlayerSessions)
+}
+
+func runRemove(ctx context.Context, c *gsectl, args []string) error {
+	return batchPlayerSessions(ctx, c, "remove", args, c.client.RemovePlayerSession, c.client.RemovePlayerSessions)
+}
+
+// batchPlayerSessions uses the single call for one id, so its error comes
+// back as the exit status, and the batch call for more.
+func batchPlayerSessions(ctx context.Context, c *gsectl, name string, args []string,
+	single func(context.Context, *apiclient.PlayerSessionRequest) error,
+	batch func(context.Context, *apiclient.BatchPlayerSessionRequest) (*apiclient.BatchPlayerSessionResponse, error)) error {
+	fs := newFlagSet(name)
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	if fs.NArg() == 1 {
+		if err := single(ctx, &apiclient.PlayerSessionRequest{PlayerSessionId: fs.Arg(0)}); err != nil {
+			return err
+		}
+		resp := &apiclient.BatchPlayerSessionResponse{
+			Results: []*apiclient.PlayerSessionResult{{PlayerSessionId: fs.Arg(0), Code: "OK"}},
+		}
+		return c.print(resp, func(t *table) { printBatchResults(t, resp.Results) })
+	}
+
+	resp, err := batch(ctx, &apiclient.BatchPlayerSessionRequest{PlayerSessionIds: fs.Args()})
+	if err != nil {
+		return err
+	}
+	if err := c.print(resp, func(t *table) { printBatchResults(t, resp.Results) }); err != nil {
+		return err
+	}
+	for _, r := range resp.Results {
+		if r.Code != "OK" {
+			return fmt.Errorf("%s failed for some player sessions", name)
+		}
+	}
+	return nil
+}
+
+func runPolicy(ctx context.Context, c *gsectl, args []string) error {
+	fs := newFlagSet("policy")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	policy := &apiclient.PlayerSessionCreationPolicyRequest{NewPlayerSessionCreationPolicy: strings.ToUpper(fs.Arg(0))}
+	if err := c.client.UpdatePlayerSessionCreationPolicy(ctx, policy); err != nil {
+		return err
+	}
+	return c.done("policy set to " + strings.ToUpper(fs.Arg(0)))
+}
+
+func runCustomData(ctx context.Context, c *gsectl, args []string) error {
+	fs := newFlagSet("custom-data")
+	push := fs.Bool("push", false, "hand the counters to the wrapper's automatic reporter instead of reporting now")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	current, err := strconv.ParseInt(fs.Arg(0), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid CURRENT %q", fs.Arg(0))
+	}
+	max, err := strconv.ParseInt(fs.Arg(1), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid MAX %q", fs.Arg(1))
+	}
+
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	report := c.client.ReportCustomData
+	if *push {
+		report = c.client.PushCustomData
+	}
+	if err := report(ctx, &apiclient.CustomDataRequest{CurrentCustomCount: int32(current), MaxCustomCount: int32(max)}); err != nil {
+		return err
+	}
+	return c.done(fmt.Sprintf("custom data %d/%d reported", current, max))
+}
+
+func runHealth(ctx context.Context, c *gsectl, args []string) error {
+	fs := newFlagSet("health")
+	fs.Parse(args)
+
+	switch fs.Arg(0) {
+	case "":
+		return showHealth(ctx, c)
+	case "on", "off":
+		ctx, cancel := c.call(ctx)
+		defer cancel()
+		if err := c.client.SetHealthStatus(ctx, &apiclient.HealthStatusRequest{HealthStatus: fs.Arg(0) == "on"}); err != nil {
+			return err
+		}
+		return c.done("health " + fs.Arg(0))
+	}
+	fs.Usage()
+	os.Exit(2)
+	return nil
+}
+
+func runTerminate(ctx context.Context, c *gsectl, args []string) error {
+	fs := newFlagSet("terminate")
+	in := fs.Duration("in", 0, "the termination time, this far from now; now when 0")
+	via := fs.String("via", "grpc", "grpc calls OnProcessTerminate like the agent does; http uses the -standalone route")
+	fs.Parse(args)
+
+	terminationTime := time.Now().Add(*in).Unix()
+	switch *via {
+	case "grpc":
+		if err := terminateByGrpc(ctx, c, terminationTime); err != nil {
+			return err
+		}
+	case "http":
+		if err := terminateByHttp(ctx, c, terminationTime); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown -via %q, expect grpc or http", *via)
+	}
+	return c.done(fmt.Sprintf("terminate sent, terminationTime %d; watch progress with gsectl status", terminationTime))
+}
+
+func runEvents(ctx context.Context, c *gsectl, args []string) error {
+	fs := newFlagSet("events")
+	types := fs.String("types", "", "comma separated event types, all when empty")
+	replay := fs.Int("replay", 0, "kept events to show first, -1 for all kept")
+	fs.Parse(args)
+
+	params := &apiclient.StreamEventsParams{Replay: *replay}
+	if *types != "" {
+		params.Types = strings.Split(*types, ",")
+	}
+
+	if c.output == "table" {
+		printEventHeader()
+	}
+	pause := time.Second
+	for {
+		last, err := c.client.StreamEvents(ctx, params, func(ev *apiclient.Event) error {
+			pause = time.Second
+			if c.output == "json" {
+				return c.printJSONLine(ev)
+			}
+			printEvent(ev)
+			return nil
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if apiErr := (*apiclient.Error)(nil); errors.As(err, &apiErr) {
+			return err
+		}
+
+		// 服务端在客户端跟不上时会断开，带上最后的 seq 重连
+		fmt.Fprintf(os.Stderr, "gsectl: event stream ended (%v), reconnecting\n", err)
+		params.LastEventId = last
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pause):
+		}
+		if pause < 10*time.Second {
+			pause *= 2
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"supertuxkart/apiclient"
+	"text/tabwriter"
+	"time"
+)
+
+type table struct {
+	w *tabwriter.Writer
+}
+
+func (c *gsectl) newTable() *table {
+	return &table{w: tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)}
+}
+
+func (t *table) row(cells ...string) {
+	fmt.Fprintln(t.w, strings.Join(cells, "\t"))
+}
+
+// note 打印在表格之后，不参与对齐
+func (t *table) note(format string, args ...interface{}) {
+	t.flush()
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func (t *table) flush() {
+	t.w.Flush()
+}
+
+// print writes v as JSON with -o json, or the table fill builds.
+func (c *gsectl) print(v interface{}, fill func(t *table)) error {
+	if c.output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	t := c.newTable()
+	fill(t)
+	t.flush()
+	return nil
+}
+
+func (c *gsectl) printJSONLine(v interface{}) error {
+	return json.NewEncoder(os.Stdout).Encode(v)
+}
+
+// done reports a command without a result.
+func (c *gsectl) done(message string) error {
+	if c.output == "json" {
+		return c.printJSONLine(map[string]string{"result": "OK", "message": message})
+	}
+	fmt.Println(message)
+	return nil
+}
+
+func unixMilli(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).Format(time.RFC3339)
+}
+
+// 玩家会话的时间是 unix 秒
+func unixSeconds(s int64) string {
+	if s == 0 {
+		return "-"
+	}
+	return time.Unix(s, 0).Format(time.RFC3339)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func printStatus(t *table, st *apiclient.WrapperStatus) {
+	t.row("STATE", st.State)
+	t.row("PID", strconv.Itoa(int(st.Pid)))
+	t.row("GAME SERVER SESSION", orDash(st.GameServerSessionId))
+	t.row("PORTS", fmt.Sprintf("client %d, grpc %d, http %d", st.ClientPort, st.GrpcPort, st.HttpPort))
+	t.row("STARTED", fmt.Sprintf("%s (%s ago)", unixMilli(st.StartTime), time.Duration(st.UptimeSeconds)*time.Second))
+	t.row("HEALTHY", strconv.FormatBool(st.Healthy))
+	t.row("POLICY", orDash(st.PlayerSessionCreationPolicy))
+	t.row("DRAINING", strconv.FormatBool(st.Draining))
+	maxPlayers := "unlimited"
+	if st.MaxPlayers > 0 {
+		maxPlayers = strconv.Itoa(int(st.MaxPlayers))
+	}
+	t.row("PLAYER SESSIONS", fmt.Sprintf("%d / %s", st.PlayerSessions, maxPlayers))
+	if st.TerminationPhase != "" {
+		t.row("TERMINATION", fmt.Sprintf("%s, deadline %s", st.TerminationPhase, unixMilli(st.TerminationDeadline)))
+		if st.TerminationError != "" {
+			t.row("TERMINATION ERROR", st.TerminationError)
+		}
+	}
+}
+
+func printSession(t *table, s *apiclient.GameServerSession) {
+	t.row("ID", orDash(s.GameServerSessionId))
+	t.row("NAME", orDash(s.Name))
+	t.row("FLEET", orDash(s.FleetId))
+	t.row("ADDRESS", fmt.Sprintf("%s:%d", orDash(s.IpAddress), s.Port))
+	t.row("DNS NAME", orDash(s.DnsName))
+	t.row("MAX PLAYERS", strconv.Itoa(int(s.MaxPlayers)))
+	t.row("JOINABLE", strconv.FormatBool(s.Joinable))
+	for _, p := range s.GameProperties {
+		t.row("PROPERTY "+p.Key, p.Value)
+	}
+	if s.GameServerSessionData != "" {
+		t.row("SESSION DATA", s.GameServerSessionData)
+	}
+	if s.MatchmakerData != "" {
+		t.row("MATCHMAKER DATA", s.MatchmakerData)
+	}
+}
+
+func printPlayerSessions(t *table, sessions []*apiclient.PlayerSession) {
+	t.row("PLAYER SESSION", "PLAYER", "STATUS", "CREATED", "TERMINATED")
+	for _, s := range sessions {
+		t.row(s.PlayerSessionId, orDash(s.PlayerId), s.Status,
+			unixSeconds(s.CreationTime), unixSeconds(s.TerminationTime))
+	}
+}
+
+func printCachedPlayerSessions(t *table, sessions []*apiclient.CachedPlayerSession) {
+	t.row("PLAYER SESSION", "PLAYER", "STATUS", "CONNECTED", "UPDATED")
+	for _, s := range sessions {
+		t.row(s.PlayerSessionId, orDash(s.PlayerId), s.Status, strconv.FormatBool(s.Connected), unixMilli(s.UpdateTime))
+	}
+}
+
+func printBatchResults(t *table, results []*apiclient.PlayerSessionResult) {
+	t.row("PLAYER SESSION", "CODE", "MESSAGE")
+	for _, r := range results {
+		t.row(r.PlayerSessionId, r.Code, r.Message)
+	}
+}
+
+// 事件是逐条打印的，tabwriter 对不齐，用固定列宽
+const eventRowFormat = "%-6s  %-20s  %-24s  %s\n"
+
+func printEventHeader() {
+	fmt.Printf(eventRowFormat, "SEQ", "TIME", "TYPE", "DETAIL")
+}
+
+func printEvent(ev *apiclient.Event) {
+	var detail []string
+	add := func(key, value string) {
+		if value != "" {
+			detail = append(detail, key+"="+value)
+		}
+	}
+	if ev.GrpcPort != 0 {
+		add("grpcPort", strconv.Itoa(ev.GrpcPort))
+	}
+	add("session", ev.GameServerSessionId)
+	add("player", ev.PlayerId)
+	add("playerSession", ev.PlayerSessionId)
+	add("policy", ev.Policy)
+	if ev.Healthy != nil {
+		add("healthy", strconv.FormatBool(*ev.Healthy))
+	}
+	if ev.TerminationTime != 0 {
+		add("terminationTime", strconv.FormatInt(ev.TerminationTime, 10))
+	}
+	fmt.Printf(eventRowFormat, strconv.FormatUint(ev.Seq, 10), ev.Time.Format(time.RFC3339), ev.Type,
+		strings.Join(detail, " "))
+}
@@ -0,0 +1,40 @@
+// hookreceiver is a local stand-in for a webhook consumer: it prints every
+// event the wrapper posts to it, one JSON line each.
+//
+//	hookreceiver -addr 127.0.0.1:9000
+//
+// with a -hooks-config webhook url of http://127.0.0.1:9000/events.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"supertuxkart/events"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9000", "the address to listen on")
+	flag.Parse()
+
+	enc := json.NewEncoder(os.Stdout)
+	http.HandleFunc("/events", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var ev events.Event
+		if err := json.NewDecoder(req.Body).Decode(&ev); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		enc.Encode(ev)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("hookreceiver listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
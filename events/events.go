@@ -0,0 +1,226 @@
+package events
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"supertuxkart/logger"
+	"sync"
+	"time"
+)
+
+// Type 事件类型
+type Type string
+
+const (
+	ProcessReady          Type = "process_ready"           // ProcessReady 成功
+	ServerReady           Type = "server_ready"            // STK 开始监听
+	SessionStart          Type = "session_start"           // OnStartGameServerSession
+	SessionActivated      Type = "session_activated"       // ActivateGameServerSession 成功
+	PlayerJoin            Type = "player_join"             // 日志 New player
+	PlayerLeave           Type = "player_leave"            // 日志 disconnected
+	NoPlayers             Type = "no_players"              // 日志 0 peers
+	PlayerSessionAccepted Type = "player_session_accepted" // AcceptPlayerSession 成功
+	PlayerSessionRemoved  Type = "player_session_removed"  // RemovePlayerSession 成功
+	PolicyChanged         Type = "policy_changed"          // 玩家会话创建策略变化
+	HealthChanged         Type = "health_changed"          // SetHealthStatus 改变了健康状态
+	Terminate             Type = "terminate"               // OnProcessTerminate
+	ProcessEnded          Type = "process_ended"           // ProcessEnding 成功
+)
+
+// Event is one wrapper lifecycle event. Only the fields that apply to Type
+// are set.
+type Event struct {
+	Seq  uint64    `json:"seq"`
+	Type Type      `json:"type"`
+	Time time.Time `json:"time"`
+	// GrpcPort 是发布事件的游戏进程的 wrapper grpc 端口，多进程时用来区分
+	GrpcPort int `json:"grpcPort,omitempty"`
+
+	GameServerSessionId string `json:"gameServerSessionId,omitempty"`
+	PlayerId            string `json:"playerId,omitempty"`
+	PlayerSessionId     string `json:"playerSessionId,omitempty"`
+	Policy              string `json:"policy,omitempty"`
+	Healthy             *bool  `json:"healthy,omitempty"`
+	TerminationTime     int64  `json:"terminationTime,omitempty"`
+}
+
+// subscriberBuffer 订阅者来不及处理时缓存的事件数，满了丢弃
+const subscriberBuffer = 64
+
+// DefaultHistorySize 保留最近的事件数，供 SSE 重放
+const DefaultHistorySize = 100
+
+type subscriber struct {
+	name    string
+	ch      chan Event
+	dropped int64
+	// closeOnDrop 的订阅者跟不上时直接关闭，由对方带上最后的 seq 重新订阅
+	closeOnDrop bool
+	closeOnce   sync.Once
+}
+
+// close must be called with mu held.
+func (s *subscriber) close() {
+	s.closeOnce.Do(func() {
+		delete(subscribers, s)
+		close(s.ch)
+	})
+}
+
+var (
+	mu          sync.Mutex
+	seq         uint64
+	subscribers = make(map[*subscriber]bool)
+
+	history     []Event
+	historySize = DefaultHistorySize
+)
+
+// SetHistorySize sets how many recent events are kept for replay.
+func SetHistorySize(n int) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+	historySize = n
+	if len(history) > n {
+		history = append([]Event(nil), history[len(history)-n:]...)
+	}
+}
+
+// Publish stamps ev with a sequence number and time and hands it to every
+// subscriber. It never blocks: a subscriber whose buffer is full misses ev.
+func Publish(ev Event) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	seq++
+	ev.Seq = seq
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	if historySize > 0 {
+		if len(history) >= historySize {
+			history = history[1:]
+		}
+		history = append(history, ev)
+	}
+
+	for s := range subscribers {
+		select {
+		case s.ch <- ev:
+		default:
+			s.dropped++
+			logger.Warn("event dropped, subscriber too slow", zap.String("subscriber", s.name),
+				zap.String("type", string(ev.Type)), zap.Int64("dropped", s.dropped))
+			if s.closeOnDrop {
+				s.close()
+			}
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every event published from now on
+// and a cancel func that unsubscribes and closes it.
+func Subscribe(name string) (<-chan Event, func()) {
+	s := &subscriber{
+		name: name,
+		ch:   make(chan Event, subscriberBuffer),
+	}
+
+	mu.Lock()
+	subscribers[s] = true
+	mu.Unlock()
+
+	return s.ch, cancelFunc(s)
+}
+
+func cancelFunc(s *subscriber) func() {
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		s.close()
+	}
+}
+
+// Replay is like Subscribe but also returns the kept events that follow
+// afterSeq, or the last n kept events (all when n < 0) when afterSeq is 0,
+// with no gap
+// between them and the channel. Unlike Subscribe, the channel is closed when
+// the subscriber falls behind; the caller then resubscribes from the last
+// Seq it saw.
+func Replay(name string, afterSeq uint64, n int) ([]Event, <-chan Event, func()) {
+	s := &subscriber{
+		name:        name,
+		ch:          make(chan Event, subscriberBuffer),
+		closeOnDrop: true,
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var replay []Event
+	if afterSeq > 0 {
+		for _, ev := range history {
+			if ev.Seq > afterSeq {
+				replay = append(replay, ev)
+			}
+		}
+	} else if n != 0 {
+		start := len(history) - n
+		if n < 0 || start < 0 {
+			start = 0
+		}
+		replay = append(replay, history[start:]...)
+	}
+
+	subscribers[s] = true
+	return replay, s.ch, cancelFunc(s)
+}
+
+// Handle runs fn for each event of the given types (all types when none are
+// given) on its own goroutine, in publish order.
+func Handle(name string, fn func(Event), types ...Type) func() {
+	ch, cancel := Subscribe(name)
+	go func() {
+		for ev := range ch {
+			if Matches(ev.Type, types) {
+				fn(ev)
+			}
+		}
+	}()
+	return cancel
+}
+
+// Matches reports whether t is one of types; no types matches everything.
+func Matches(t Type, types []Type) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, want := range types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTypes parses a comma separated list of event types.
+func ParseTypes(csv string) ([]Type, error) {
+	var types []Type
+	for _, s := range strings.Split(csv, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		if !isType(Type(s)) {
+			return nil, fmt.Errorf("unknown event type %q", s)
+		}
+		types = append(types, Type(s))
+	}
+	return types, nil
+}
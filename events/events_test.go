@@ -0,0 +1,110 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+// resetHistory 清空保留的事件，只保留最近 n 个
+func resetHistory(t *testing.T, n int) {
+	t.Helper()
+
+	SetHistorySize(0)
+	SetHistorySize(n)
+	t.Cleanup(func() { SetHistorySize(DefaultHistorySize) })
+}
+
+func publishPlayers(ids ...string) {
+	for _, id := range ids {
+		Publish(Event{Type: PlayerJoin, PlayerId: id})
+	}
+}
+
+func playerIds(evs []Event) []string {
+	ids := make([]string, 0, len(evs))
+	for _, ev := range evs {
+		ids = append(ids, ev.PlayerId)
+	}
+	return ids
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestReplay(t *testing.T) {
+	resetHistory(t, 3)
+	publishPlayers("p1", "p2", "p3", "p4", "p5")
+
+	all, _, cancel := Replay("test", 0, -1)
+	cancel()
+	if got, want := playerIds(all), []string{"p3", "p4", "p5"}; !equalStrings(got, want) {
+		t.Fatalf("replay all = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name     string
+		afterSeq uint64
+		n        int
+		want     []string
+	}{
+		{name: "last 2", n: 2, want: []string{"p4", "p5"}},
+		{name: "more than kept", n: 10, want: []string{"p3", "p4", "p5"}},
+		{name: "none", n: 0, want: []string{}},
+		{name: "after p3", afterSeq: all[0].Seq, n: 0, want: []string{"p4", "p5"}},
+		{name: "after seq wins over n", afterSeq: all[1].Seq, n: -1, want: []string{"p5"}},
+		{name: "after the last", afterSeq: all[2].Seq, n: -1, want: []string{}},
+	}
+	for _, tt := range tests {
+		replay, _, cancel := Replay("test", tt.afterSeq, tt.n)
+		cancel()
+		if got := playerIds(replay); !equalStrings(got, tt.want) {
+			t.Errorf("%s: replay = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReplayHasNoGap(t *testing.T) {
+	resetHistory(t, 10)
+	publishPlayers("p1", "p2")
+
+	replay, ch, cancel := Replay("test", 0, -1)
+	defer cancel()
+	publishPlayers("p3")
+
+	select {
+	case ev := <-ch:
+		if last := replay[len(replay)-1].Seq; ev.Seq != last+1 {
+			t.Errorf("first live event seq = %d, want %d", ev.Seq, last+1)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no live event")
+	}
+}
+
+func TestReplayClosesSlowSubscriber(t *testing.T) {
+	resetHistory(t, 0)
+
+	_, ch, cancel := Replay("slow", 0, -1)
+	defer cancel()
+	for i := 0; i <= subscriberBuffer; i++ {
+		publishPlayers("p")
+	}
+
+	// 跟不上的订阅者收到缓存的事件后通道关闭
+	received := 0
+	for range ch {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("received %d events before close, want %d", received, subscriberBuffer)
+	}
+}
@@ -0,0 +1,221 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"supertuxkart/logger"
+	"time"
+)
+
+// allTypes 所有事件类型，用于校验配置
+var allTypes = []Type{ProcessReady, ServerReady, SessionStart, SessionActivated, PlayerJoin, PlayerLeave,
+	NoPlayers, PlayerSessionAccepted, PlayerSessionRemoved, PolicyChanged, HealthChanged, Terminate, ProcessEnded}
+
+// Duration is a time.Duration written as "2s" in the hook config.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// HookConfig is the -hooks-config file, for example:
+//
+//	{
+//	  "webhooks": [{"url": "http://127.0.0.1:9000/events", "events": ["player_join"], "timeout": "2s"}],
+//	  "scripts": {"on_session_start": "/local/game/hooks/session_start.sh"},
+//	  "scriptTimeout": "10s"
+//	}
+//
+// Script keys are "on_" plus an event type, e.g. on_player_join or
+// on_terminate.
+type HookConfig struct {
+	Webhooks      []WebhookConfig   `json:"webhooks"`
+	Scripts       map[string]string `json:"scripts"`
+	ScriptTimeout Duration          `json:"scriptTimeout"`
+}
+
+// WebhookConfig posts each event, as JSON, to URL. An empty Events means all
+// types.
+type WebhookConfig struct {
+	URL     string   `json:"url"`
+	Events  []Type   `json:"events"`
+	Timeout Duration `json:"timeout"`
+}
+
+const (
+	defaultWebhookTimeout = 2 * time.Second
+	defaultScriptTimeout  = 10 * time.Second
+	webhookAttempts       = 3
+)
+
+func LoadHookConfig(path string) (*HookConfig, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := new(HookConfig)
+	if err := json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", path, err)
+	}
+	return config, nil
+}
+
+func isType(t Type) bool {
+	for _, known := range allTypes {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
+
+// StartHooks validates config and subscribes its webhooks and scripts.
+func StartHooks(config *HookConfig) error {
+	for _, w := range config.Webhooks {
+		if err := validateWebhook(w); err != nil {
+			return err
+		}
+	}
+	for name, path := range config.Scripts {
+		if !strings.HasPrefix(name, "on_") || !isType(Type(strings.TrimPrefix(name, "on_"))) {
+			return fmt.Errorf("unknown script hook %q, expect on_ followed by one of %v", name, allTypes)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("script hook %s: %v", name, err)
+		}
+	}
+
+	for _, w := range config.Webhooks {
+		startWebhook(w)
+	}
+
+	scriptTimeout := time.Duration(config.ScriptTimeout)
+	if scriptTimeout <= 0 {
+		scriptTimeout = defaultScriptTimeout
+	}
+	for name, path := range config.Scripts {
+		startScript(name, path, scriptTimeout)
+	}
+	return nil
+}
+
+// validateWebhook 只允许回环地址，事件里有会话和玩家信息
+func validateWebhook(w WebhookConfig) error {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return fmt.Errorf("webhook url %q: %v", w.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook url %q: scheme must be http or https", w.URL)
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("webhook url %q: host must be a loopback address", w.URL)
+	}
+	for _, t := range w.Events {
+		if !isType(t) {
+			return fmt.Errorf("webhook url %q: unknown event type %q", w.URL, t)
+		}
+	}
+	return nil
+}
+
+func startWebhook(w WebhookConfig) {
+	timeout := time.Duration(w.Timeout)
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	Handle("webhook "+w.URL, func(ev Event) {
+		body, _ := json.Marshal(ev)
+		for attempt := 1; attempt <= webhookAttempts; attempt++ {
+			err := postEvent(client, w.URL, body)
+			if err == nil {
+				return
+			}
+			logger.Warn("webhook fail", zap.String("url", w.URL), zap.String("type", string(ev.Type)),
+				zap.Int("attempt", attempt), zap.Error(err))
+			time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
+		}
+	}, w.Events...)
+	logger.Info("webhook registered", zap.String("url", w.URL), zap.Any("events", w.Events))
+}
+
+func postEvent(client *http.Client, target string, body []byte) error {
+	resp, err := client.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// startScript runs path for every matching event with the event as JSON on
+// stdin and its fields in GSE_* environment variables (see eventEnv).
+func startScript(name, path string, timeout time.Duration) {
+	Handle("script "+name, func(ev Event) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		body, _ := json.Marshal(ev)
+		cmd := exec.CommandContext(ctx, path) // #nosec
+		cmd.Stdin = bytes.NewReader(body)
+		cmd.Env = append(os.Environ(), eventEnv(ev)...)
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			logger.Warn("hook script fail", zap.String("hook", name), zap.String("path", path),
+				zap.ByteString("output", out), zap.Error(err))
+			return
+		}
+		logger.Info("hook script done", zap.String("hook", name), zap.ByteString("output", out))
+	}, Type(strings.TrimPrefix(name, "on_")))
+	logger.Info("script hook registered", zap.String("hook", name), zap.String("path", path))
+}
+
+func eventEnv(ev Event) []string {
+	env := []string{
+		"GSE_EVENT_TYPE=" + string(ev.Type),
+		"GSE_EVENT_SEQ=" + strconv.FormatUint(ev.Seq, 10),
+		"GSE_EVENT_TIME=" + ev.Time.Format(time.RFC3339Nano),
+		"GSE_GAME_SERVER_SESSION_ID=" + ev.GameServerSessionId,
+		"GSE_PLAYER_ID=" + ev.PlayerId,
+		"GSE_PLAYER_SESSION_ID=" + ev.PlayerSessionId,
+	}
+	if ev.Policy != "" {
+		env = append(env, "GSE_POLICY="+ev.Policy)
+	}
+	if ev.Healthy != nil {
+		env = append(env, "GSE_HEALTHY="+strconv.FormatBool(*ev.Healthy))
+	}
+	if ev.TerminationTime != 0 {
+		env = append(env, "GSE_TERMINATION_TIME="+strconv.FormatInt(ev.TerminationTime, 10))
+	}
+	return env
+}
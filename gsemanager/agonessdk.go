@@ -0,0 +1,203 @@
+package gsemanager
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"supertuxkart/agones"
+	"supertuxkart/events"
+	"supertuxkart/logger"
+	"sync"
+	"time"
+)
+
+// agonesSdk is the Sdk of -backend=agones. Agones has no player sessions,
+// creation policy or custom data, so those stay in memory as with LocalSdk;
+// readiness, the end of the session and of the process, and the players
+// seen in the game log go to the SDK sidecar. After Ready it pings the
+// sidecar while the game is healthy and watches the GameServer: being
+// Allocated stands in for OnStartGameServerSession, so the local cache,
+// status and events work the same way with either backend.
+type agonesSdk struct {
+	*LocalSdk
+	client         *agones.Client
+	playerTracking bool
+	healthy        func() bool
+	healthInterval time.Duration
+	g              *GseManager
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	shutdown bool
+}
+
+// NewAgonesSdk returns the Sdk of the process pid behind the sidecar client
+// talks to. healthy is polled every healthInterval and a ping is sent while
+// it returns true. Players are only reported with playerTracking, which
+// needs the Agones PlayerTracking feature gate.
+func NewAgonesSdk(pid string, client *agones.Client, playerTracking bool, healthy func() bool,
+	healthInterval time.Duration) Sdk {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &agonesSdk{
+		LocalSdk:       NewLocalSdk(pid),
+		client:         client,
+		playerTracking: playerTracking,
+		healthy:        healthy,
+		healthInterval: healthInterval,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+func (s *agonesSdk) bind(g *GseManager) {
+	s.g = g
+}
+
+// Ready marks the GameServer Ready, then starts the health pings and the
+// GameServer watch. logPaths and grpcPort only matter to the GSE agent.
+func (s *agonesSdk) Ready(ctx context.Context, logPaths []string, clientPort, grpcPort int32) error {
+	if err := s.client.Ready(ctx); err != nil {
+		return status.Errorf(codes.Unavailable, "agones ready: %v", err)
+	}
+
+	go s.pingHealth()
+	go s.watch()
+	return nil
+}
+
+// TerminateSession shuts the GameServer down: an Agones GameServer hosts a
+// single allocation, so ending the session ends it too.
+func (s *agonesSdk) TerminateSession(ctx context.Context, gameServerSessionId string) error {
+	return s.shutdownOnce(ctx)
+}
+
+// End shuts the GameServer down and stops the pings and the watch.
+func (s *agonesSdk) End(ctx context.Context) error {
+	if err := s.shutdownOnce(ctx); err != nil {
+		return err
+	}
+	s.cancel()
+	return nil
+}
+
+// shutdownOnce 会话结束和进程结束都会调用，只向 sidecar 发一次 /shutdown
+func (s *agonesSdk) shutdownOnce(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shutdown {
+		return nil
+	}
+	logger.Info("start to agones shutdown")
+	if err := s.client.Shutdown(ctx); err != nil {
+		logger.Info("agones shutdown fail", zap.Error(err))
+		return status.Errorf(codes.Unavailable, "agones shutdown: %v", err)
+	}
+	s.shutdown = true
+	return nil
+}
+
+func (s *agonesSdk) PlayerConnected(ctx context.Context, playerId string) error {
+	if !s.playerTracking {
+		return nil
+	}
+	if err := s.client.PlayerConnect(ctx, playerId); err != nil {
+		return status.Errorf(codes.Unavailable, "agones player connect: %v", err)
+	}
+	return nil
+}
+
+func (s *agonesSdk) PlayerDisconnected(ctx context.Context, playerId string) error {
+	if !s.playerTracking {
+		return nil
+	}
+	if err := s.client.PlayerDisconnect(ctx, playerId); err != nil {
+		return status.Errorf(codes.Unavailable, "agones player disconnect: %v", err)
+	}
+	return nil
+}
+
+func (s *agonesSdk) pingHealth() {
+	ticker := time.NewTicker(s.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// 不健康时停止 ping，由 Agones 标记 Unhealthy
+		if !s.healthy() {
+			continue
+		}
+		if err := s.client.Health(s.ctx); err != nil {
+			logger.Info("agones health ping fail", zap.Error(err))
+		}
+	}
+}
+
+// watch follows the GameServer and reconnects when the stream breaks.
+func (s *agonesSdk) watch() {
+	for {
+		err := s.client.WatchGameServer(s.ctx, s.onGameServer)
+		if s.ctx.Err() != nil {
+			return
+		}
+		logger.Info("agones watch ended, retrying", zap.Error(err))
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (s *agonesSdk) onGameServer(gs *agones.GameServer) {
+	switch gs.Status.State {
+	case agones.StateAllocated:
+		// 同一个 GameServer 只会被分配一次，watch 会重复推送
+		if s.g.GameServerSessionId() == gs.ObjectMeta.Name {
+			return
+		}
+		s.allocated(gs)
+	case agones.StateShutdown:
+		if s.g.State() == StateTerminating || s.g.State() == StateEnded {
+			return
+		}
+		logger.Info("agones gameserver shutting down", zap.String("name", gs.ObjectMeta.Name))
+		s.g.AdvanceState(StateTerminating)
+		s.g.SetDraining(true)
+		events.Publish(events.Event{Type: events.Terminate, GrpcPort: s.g.GrpcPort(),
+			GameServerSessionId: s.g.GameServerSessionId()})
+	}
+}
+
+func (s *agonesSdk) allocated(gs *agones.GameServer) {
+	name := gs.ObjectMeta.Name
+	logger.Info("agones gameserver allocated", zap.String("name", name), zap.String("address", gs.Status.Address))
+	s.g.Trace().Start(name)
+	events.Publish(events.Event{Type: events.SessionStart, GrpcPort: s.g.GrpcPort(), GameServerSessionId: name})
+
+	gameServerSession := &GameServerSession{
+		GameServerSessionId: name,
+		Name:                name,
+		IpAddress:           gs.Status.Address,
+	}
+	if len(gs.Status.Ports) > 0 {
+		gameServerSession.Port = gs.Status.Ports[0].Port
+	}
+	for key, value := range gs.ObjectMeta.Annotations {
+		gameServerSession.GameProperties = append(gameServerSession.GameProperties,
+			&GameProperty{Key: key, Value: value})
+	}
+
+	s.g.SetGameServerSession(gameServerSession)
+	s.g.AdvanceState(StateSessionActive)
+	events.Publish(events.Event{Type: events.SessionActivated, GrpcPort: s.g.GrpcPort(), GameServerSessionId: name})
+}
@@ -0,0 +1,224 @@
+package gsemanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"supertuxkart/agones"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSidecar is a minimal Agones SDK sidecar like cmd/agonessdk: it counts
+// the calls per path and streams the GameServer on /watch/gameserver.
+type fakeSidecar struct {
+	mu       sync.Mutex
+	gs       agones.GameServer
+	calls    map[string]int
+	players  []string
+	watchers map[chan agones.GameServer]bool
+}
+
+func newFakeSidecar(t *testing.T) (*fakeSidecar, *httptest.Server) {
+	t.Helper()
+
+	s := &fakeSidecar{
+		gs: agones.GameServer{
+			ObjectMeta: agones.ObjectMeta{Name: "gs-1", Namespace: "default"},
+			Status:     agones.Status{State: agones.StateScheduled, Address: "10.0.0.1"},
+		},
+		calls:    make(map[string]int),
+		watchers: make(map[chan agones.GameServer]bool),
+	}
+
+	mux := http.NewServeMux()
+	for _, path := range []string{"/ready", "/health", "/shutdown", "/alpha/player/connect", "/alpha/player/disconnect"} {
+		mux.HandleFunc(path, s.post)
+	}
+	mux.HandleFunc("/watch/gameserver", s.watch)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func (s *fakeSidecar) post(w http.ResponseWriter, req *http.Request) {
+	body := map[string]string{}
+	json.NewDecoder(req.Body).Decode(&body)
+
+	s.mu.Lock()
+	s.calls[req.URL.Path]++
+	if id, ok := body["playerID"]; ok {
+		s.players = append(s.players, req.URL.Path+" "+id)
+	}
+	s.mu.Unlock()
+
+	switch req.URL.Path {
+	case "/ready":
+		s.setState(agones.StateReady)
+	case "/shutdown":
+		s.setState(agones.StateShutdown)
+	}
+	w.Write([]byte("{}"))
+}
+
+func (s *fakeSidecar) count(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[path]
+}
+
+func (s *fakeSidecar) setState(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gs.Status.State = state
+	if state == agones.StateAllocated {
+		s.gs.Status.Ports = []agones.Port{{Name: "default", Port: 7654}}
+		s.gs.ObjectMeta.Annotations = map[string]string{"map": "volcano"}
+	}
+	for ch := range s.watchers {
+		ch <- s.gs
+	}
+}
+
+func (s *fakeSidecar) watch(w http.ResponseWriter, req *http.Request) {
+	ch := make(chan agones.GameServer, 16)
+	s.mu.Lock()
+	s.watchers[ch] = true
+	ch <- s.gs
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, ch)
+		s.mu.Unlock()
+	}()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case gs := <-ch:
+			enc.Encode(map[string]agones.GameServer{"result": gs})
+			w.(http.Flusher).Flush()
+		}
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAgonesSdk(t *testing.T) {
+	sidecar, server := newFakeSidecar(t)
+
+	var healthy int32 = 1
+	g := newTestManager(t, func(pid string) Sdk {
+		return NewAgonesSdk(pid, agones.NewClient(server.URL), true,
+			func() bool { return atomic.LoadInt32(&healthy) == 1 }, 10*time.Millisecond)
+	})
+	defer g.sdk.(*agonesSdk).cancel()
+
+	if err := g.ProcessReady(nil, 7654, 41001); err != nil {
+		t.Fatalf("ProcessReady: %v", err)
+	}
+	if sidecar.count("/ready") != 1 {
+		t.Errorf("/ready called %d times, want 1", sidecar.count("/ready"))
+	}
+
+	// 健康时持续 ping，不健康时停止
+	waitFor(t, "health pings", func() bool { return sidecar.count("/health") >= 3 })
+	atomic.StoreInt32(&healthy, 0)
+	time.Sleep(30 * time.Millisecond)
+	pings := sidecar.count("/health")
+	time.Sleep(100 * time.Millisecond)
+	if got := sidecar.count("/health"); got != pings {
+		t.Errorf("%d health pings while unhealthy", got-pings)
+	}
+
+	// Allocated 代替 OnStartGameServerSession
+	sidecar.setState(agones.StateAllocated)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := g.WaitForState(ctx, StateSessionActive); err != nil {
+		t.Fatalf("waiting for SESSION_ACTIVE: %v", err)
+	}
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession.GameServerSessionId != "gs-1" || gameServerSession.IpAddress != "10.0.0.1" ||
+		gameServerSession.Port != 7654 {
+		t.Errorf("game server session = %+v", gameServerSession)
+	}
+	if len(gameServerSession.GameProperties) != 1 || gameServerSession.GameProperties[0].Key != "map" {
+		t.Errorf("game properties = %v, want the GameServer annotations", gameServerSession.GameProperties)
+	}
+
+	g.PlayerConnected("bob")
+	g.PlayerDisconnected("bob")
+	sidecar.mu.Lock()
+	players := append([]string(nil), sidecar.players...)
+	sidecar.mu.Unlock()
+	if want := []string{"/alpha/player/connect bob", "/alpha/player/disconnect bob"}; !equalStrings(players, want) {
+		t.Errorf("player calls = %q, want %q", players, want)
+	}
+
+	// 会话结束和进程结束只发一次 /shutdown
+	if err := g.TerminateGameServerSession(); err != nil {
+		t.Fatalf("TerminateGameServerSession: %v", err)
+	}
+	if err := g.ProcessEnding(); err != nil {
+		t.Fatalf("ProcessEnding: %v", err)
+	}
+	if err := g.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if got := sidecar.count("/shutdown"); got != 1 {
+		t.Errorf("/shutdown called %d times, want 1", got)
+	}
+	if g.State() != StateEnded {
+		t.Errorf("state = %s, want ENDED", g.State())
+	}
+}
+
+func TestAgonesSdkShutdownFromWatch(t *testing.T) {
+	sidecar, server := newFakeSidecar(t)
+	g := newTestManager(t, func(pid string) Sdk {
+		return NewAgonesSdk(pid, agones.NewClient(server.URL), false, func() bool { return true }, time.Hour)
+	})
+	defer g.sdk.(*agonesSdk).cancel()
+
+	if err := g.ProcessReady(nil, 7654, 41002); err != nil {
+		t.Fatalf("ProcessReady: %v", err)
+	}
+	sidecar.setState(agones.StateAllocated)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := g.WaitForState(ctx, StateSessionActive); err != nil {
+		t.Fatalf("waiting for SESSION_ACTIVE: %v", err)
+	}
+
+	// 没开 player tracking 时不上报玩家
+	g.PlayerConnected("bob")
+	if got := sidecar.count("/alpha/player/connect"); got != 0 {
+		t.Errorf("/alpha/player/connect called %d times without player tracking", got)
+	}
+
+	// GameServer 被 Agones 关闭时进入 TERMINATING 并 drain
+	sidecar.setState(agones.StateShutdown)
+	if err := g.WaitForState(ctx, StateTerminating); err != nil {
+		t.Fatalf("waiting for TERMINATING: %v", err)
+	}
+	waitFor(t, "draining", func() bool { return g.Status().Draining })
+}
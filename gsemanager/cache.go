@@ -0,0 +1,343 @@
+package gsemanager
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"sort"
+	"supertuxkart/logger"
+	"sync"
+	"time"
+)
+
+// CachedPlayerSession is the wrapper's local view of a player session.
+// Connected is set from the game log when a player whose name equals
+// PlayerId joins.
+type CachedPlayerSession struct {
+	PlayerSessionId     string              `json:"playerSessionId"`
+	PlayerId            string              `json:"playerId"`
+	GameServerSessionId string              `json:"gameServerSessionId"`
+	Status              PlayerSessionStatus `json:"status"`
+	Connected           bool                `json:"connected"`
+	UpdateTime          time.Time           `json:"updateTime"`
+
+	// acceptTime 首次看到 ACTIVE 的时间，joined 表示日志里出现过该玩家
+	acceptTime time.Time
+	joined     bool
+}
+
+// PlayerSessionFilter selects cached sessions; empty fields match anything.
+type PlayerSessionFilter struct {
+	PlayerSessionId string
+	PlayerId        string
+	Status          PlayerSessionStatus
+}
+
+type playerSessionCache struct {
+	mu       sync.RWMutex
+	sessions map[string]*CachedPlayerSession
+	// players 记录日志里出现过的玩家，值表示是否在线；PlayerId 在对账后才知道
+	players map[string]bool
+}
+
+func newPlayerSessionCache() *playerSessionCache {
+	return &playerSessionCache{
+		sessions: make(map[string]*CachedPlayerSession),
+		players:  make(map[string]bool),
+	}
+}
+
+func (c *playerSessionCache) get(playerSessionId string) *CachedPlayerSession {
+	ps, ok := c.sessions[playerSessionId]
+	if !ok {
+		ps = &CachedPlayerSession{PlayerSessionId: playerSessionId}
+		c.sessions[playerSessionId] = ps
+	}
+	return ps
+}
+
+func (c *playerSessionCache) accepted(gameServerSessionId, playerSessionId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ps := c.get(playerSessionId)
+	ps.GameServerSessionId = gameServerSessionId
+	if ps.Status != PlayerSessionActive {
+		ps.acceptTime = time.Now()
+	}
+	ps.Status = PlayerSessionActive
+	ps.UpdateTime = time.Now()
+}
+
+func (c *playerSessionCache) removed(playerSessionId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ps := c.get(playerSessionId)
+	ps.Status = PlayerSessionCompleted
+	ps.Connected = false
+	ps.UpdateTime = time.Now()
+}
+
+// resetPlayers forgets the players seen in the log of the previous session
+// and returns them for restorePlayers.
+func (c *playerSessionCache) resetPlayers() map[string]bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous := c.players
+	c.players = make(map[string]bool)
+	return previous
+}
+
+func (c *playerSessionCache) restorePlayers(players map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.players = players
+}
+
+func (c *playerSessionCache) setConnected(playerId string, connected bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.players[playerId] = connected
+
+	matched := 0
+	for _, ps := range c.sessions {
+		if ps.PlayerId == playerId && ps.Status == PlayerSessionActive {
+			ps.Connected = connected
+			ps.joined = ps.joined || connected
+			ps.UpdateTime = time.Now()
+			matched++
+		}
+	}
+	return matched
+}
+
+// replace makes the agent's view authoritative for gameServerSessionId while
+// keeping the log-derived Connected flag.
+func (c *playerSessionCache) replace(gameServerSessionId string, sessions []*PlayerSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	fresh := make(map[string]*CachedPlayerSession, len(sessions))
+	for _, s := range sessions {
+		ps := &CachedPlayerSession{
+			PlayerSessionId:     s.PlayerSessionId,
+			PlayerId:            s.PlayerId,
+			GameServerSessionId: s.GameServerSessionId,
+			Status:              s.Status,
+			UpdateTime:          now,
+		}
+		if old, ok := c.sessions[s.PlayerSessionId]; ok {
+			ps.Connected = old.Connected && ps.Status == PlayerSessionActive
+			ps.joined = old.joined
+			ps.acceptTime = old.acceptTime
+		}
+		if online, ok := c.players[s.PlayerId]; ok && s.PlayerId != "" {
+			ps.joined = true
+			ps.Connected = online && ps.Status == PlayerSessionActive
+		}
+		if ps.Status == PlayerSessionActive && ps.acceptTime.IsZero() {
+			ps.acceptTime = now
+		}
+		fresh[s.PlayerSessionId] = ps
+	}
+
+	for id, ps := range c.sessions {
+		if _, ok := fresh[id]; !ok && ps.GameServerSessionId != gameServerSessionId {
+			fresh[id] = ps
+		}
+	}
+	c.sessions = fresh
+}
+
+// occupied counts the RESERVED and ACTIVE sessions of gameServerSessionId.
+func (c *playerSessionCache) occupied(gameServerSessionId string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := 0
+	for _, ps := range c.sessions {
+		if ps.GameServerSessionId != gameServerSessionId {
+			continue
+		}
+		if ps.Status == PlayerSessionReserved || ps.Status == PlayerSessionActive {
+			count++
+		}
+	}
+	return count
+}
+
+// online counts the players the game log shows as connected.
+func (c *playerSessionCache) online() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := 0
+	for _, connected := range c.players {
+		if connected {
+			count++
+		}
+	}
+	return count
+}
+
+// unjoined returns the ACTIVE sessions of gameServerSessionId accepted
+// before deadline whose player never showed up in the game log.
+func (c *playerSessionCache) unjoined(gameServerSessionId string, deadline time.Time) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var ids []string
+	for id, ps := range c.sessions {
+		if ps.GameServerSessionId == gameServerSessionId && ps.Status == PlayerSessionActive &&
+			!ps.joined && ps.acceptTime.Before(deadline) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (c *playerSessionCache) query(filter PlayerSessionFilter) []CachedPlayerSession {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []CachedPlayerSession
+	for _, ps := range c.sessions {
+		if filter.PlayerSessionId != "" && ps.PlayerSessionId != filter.PlayerSessionId {
+			continue
+		}
+		if filter.PlayerId != "" && ps.PlayerId != filter.PlayerId {
+			continue
+		}
+		if filter.Status != "" && ps.Status != filter.Status {
+			continue
+		}
+		result = append(result, *ps)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PlayerSessionId < result[j].PlayerSessionId
+	})
+	return result
+}
+
+// QueryPlayerSessions answers from the local cache without calling the agent.
+func (g *GseManager) QueryPlayerSessions(filter PlayerSessionFilter) []CachedPlayerSession {
+	return g.playerSessions.query(filter)
+}
+
+// IsPlayerSessionAccepted reports whether playerSessionId is ACTIVE in the local cache.
+func (g *GseManager) IsPlayerSessionAccepted(playerSessionId string) bool {
+	return len(g.playerSessions.query(PlayerSessionFilter{
+		PlayerSessionId: playerSessionId,
+		Status:          PlayerSessionActive,
+	})) > 0
+}
+
+// PlayerConnected marks the active sessions of playerId as connected; it is
+// fed by "New player" lines from the game log.
+func (g *GseManager) PlayerConnected(playerId string) {
+	if g.playerSessions.setConnected(playerId, true) == 0 {
+		logger.Info("no active player session for joined player", zap.String("playerId", playerId))
+	}
+	if err := g.sdk.PlayerConnected(g.trace.Context(), playerId); err != nil {
+		logger.Info("PlayerConnected fail", zap.String("playerId", playerId), zap.Error(err))
+	}
+}
+
+func (g *GseManager) PlayerDisconnected(playerId string) {
+	g.playerSessions.setConnected(playerId, false)
+	if err := g.sdk.PlayerDisconnected(g.trace.Context(), playerId); err != nil {
+		logger.Info("PlayerDisconnected fail", zap.String("playerId", playerId), zap.Error(err))
+	}
+}
+
+// ReconcilePlayerSessions pages through DescribePlayerSessions for the current
+// game server session and replaces the cached view with the agent's.
+func (g *GseManager) ReconcilePlayerSessions() error {
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession == nil {
+		return errNoGameServerSession
+	}
+
+	sessions, err := g.AllPlayerSessions(g.trace.Context(), DescribePlayerSessionsQuery{
+		GameServerSessionId: gameServerSession.GameServerSessionId,
+	})
+	if err != nil {
+		return err
+	}
+
+	g.playerSessions.replace(gameServerSession.GameServerSessionId, sessions)
+	g.applyPlayerSessionPolicy()
+	logger.Info("player sessions reconciled", zap.String("gameServerSessionId", gameServerSession.GameServerSessionId),
+		zap.Int("count", len(sessions)))
+	return nil
+}
+
+// StartPlayerSessionReconcile reconciles the cache every interval while a
+// game server session is active.
+func (g *GseManager) StartPlayerSessionReconcile(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if g.GetGameServerSession() == nil {
+				continue
+			}
+			if err := g.ReconcilePlayerSessions(); err != nil {
+				logger.Warn("reconcile player sessions fail", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// ExpireReservations removes, through RemovePlayerSession, the accepted
+// sessions whose player has not joined the game within timeout.
+func (g *GseManager) ExpireReservations(timeout time.Duration) {
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession == nil {
+		return
+	}
+
+	deadline := time.Now().Add(-timeout)
+	if len(g.playerSessions.unjoined(gameServerSession.GameServerSessionId, deadline)) == 0 {
+		return
+	}
+	// 先对账拿到 PlayerId，匹配已经进入游戏的玩家
+	if err := g.ReconcilePlayerSessions(); err != nil {
+		logger.Warn("reconcile before reservation expiry fail", zap.Error(err))
+	}
+
+	for _, id := range g.playerSessions.unjoined(gameServerSession.GameServerSessionId, deadline) {
+		logger.Warn("player session reservation expired, player never joined",
+			zap.String("playerSessionId", id), zap.Duration("timeout", timeout))
+		g.trace.Event("gse.reservation_expired", attribute.String("playerSessionId", id))
+		if err := g.RemovePlayerSession(id); err != nil {
+			logger.Warn("remove expired player session fail", zap.String("playerSessionId", id), zap.Error(err))
+		}
+	}
+}
+
+// StartReservationExpiry checks for expired reservations every timeout/4.
+// Joins are matched by PlayerId, so the STK player name must equal the
+// player session's PlayerId.
+func (g *GseManager) StartReservationExpiry(timeout time.Duration) {
+	interval := timeout / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			g.ExpireReservations(timeout)
+		}
+	}()
+}
@@ -0,0 +1,82 @@
+package gsemanager
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+)
+
+// capacity 统计正在 accept 的玩家会话，和缓存里的 RESERVED/ACTIVE 一起与 MaxPlayers 比较
+type capacity struct {
+	mu      sync.Mutex
+	limit   int
+	pending map[string]bool
+}
+
+func newCapacity() *capacity {
+	return &capacity{
+		pending: make(map[string]bool),
+	}
+}
+
+// SetMaxPlayers caps the players of every session below the session's own
+// MaxPlayers; 0 means no extra cap.
+func (g *GseManager) SetMaxPlayers(maxPlayers int) {
+	g.capacity.mu.Lock()
+	g.capacity.limit = maxPlayers
+	g.capacity.mu.Unlock()
+
+	g.applyPlayerSessionPolicy()
+}
+
+// MaxPlayers returns the player cap of the current session, the smaller of
+// its MaxPlayers and SetMaxPlayers, or 0 when there is none.
+func (g *GseManager) MaxPlayers() int {
+	return g.maxPlayersOf(g.GetGameServerSession())
+}
+
+func (g *GseManager) maxPlayersOf(gameServerSession *GameServerSession) int {
+	g.capacity.mu.Lock()
+	limit := g.capacity.limit
+	g.capacity.mu.Unlock()
+
+	if gameServerSession == nil || gameServerSession.MaxPlayers <= 0 {
+		return limit
+	}
+	if limit > 0 && limit < int(gameServerSession.MaxPlayers) {
+		return limit
+	}
+	return int(gameServerSession.MaxPlayers)
+}
+
+// reserveSlot holds a slot for playerSessionId while it is being accepted
+// and fails with ResourceExhausted when the session is full. Sessions that
+// are already ACTIVE need no new slot. The returned func releases the hold.
+func (g *GseManager) reserveSlot(gameServerSession *GameServerSession, playerSessionId string) (func(), error) {
+	if g.IsPlayerSessionAccepted(playerSessionId) {
+		return func() {}, nil
+	}
+
+	maxPlayers := g.maxPlayersOf(gameServerSession)
+
+	c := g.capacity
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending[playerSessionId] {
+		return nil, status.Errorf(codes.Aborted, "player session %s is already being accepted", playerSessionId)
+	}
+
+	used := g.playerSessions.occupied(gameServerSession.GameServerSessionId) + len(c.pending)
+	if maxPlayers > 0 && used >= maxPlayers {
+		return nil, status.Errorf(codes.ResourceExhausted, "game server session %s is full (%d/%d players)",
+			gameServerSession.GameServerSessionId, used, maxPlayers)
+	}
+
+	c.pending[playerSessionId] = true
+	return func() {
+		c.mu.Lock()
+		delete(c.pending, playerSessionId)
+		c.mu.Unlock()
+	}, nil
+}
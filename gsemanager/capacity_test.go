@@ -0,0 +1,61 @@
+package gsemanager
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestReserveSlot(t *testing.T) {
+	g, gameServerSession := newLocalTestManager(t, 2)
+
+	releaseA, err := g.reserveSlot(gameServerSession, "psess-a")
+	if err != nil {
+		t.Fatalf("reserve a: %v", err)
+	}
+	if _, err := g.reserveSlot(gameServerSession, "psess-a"); status.Code(err) != codes.Aborted {
+		t.Errorf("reserve a twice: got %v, want Aborted", err)
+	}
+	if _, err := g.reserveSlot(gameServerSession, "psess-b"); err != nil {
+		t.Fatalf("reserve b: %v", err)
+	}
+	if _, err := g.reserveSlot(gameServerSession, "psess-c"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("reserve c when full: got %v, want ResourceExhausted", err)
+	}
+
+	releaseA()
+	if _, err := g.reserveSlot(gameServerSession, "psess-c"); err != nil {
+		t.Errorf("reserve c after releasing a: %v", err)
+	}
+}
+
+func TestReserveSlotCountsAcceptedSessions(t *testing.T) {
+	g, gameServerSession := newLocalTestManager(t, 1)
+	g.playerSessions.accepted(gameServerSession.GameServerSessionId, "psess-a")
+
+	// 已经 ACTIVE 的会话不需要新的位置
+	release, err := g.reserveSlot(gameServerSession, "psess-a")
+	if err != nil {
+		t.Fatalf("reserve accepted a: %v", err)
+	}
+	release()
+
+	if _, err := g.reserveSlot(gameServerSession, "psess-b"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("reserve b with a accepted: got %v, want ResourceExhausted", err)
+	}
+}
+
+func TestReserveSlotMaxPlayers(t *testing.T) {
+	g, gameServerSession := newLocalTestManager(t, 4)
+	g.SetMaxPlayers(1)
+
+	if got := g.MaxPlayers(); got != 1 {
+		t.Errorf("MaxPlayers() = %d, want 1", got)
+	}
+	if _, err := g.reserveSlot(gameServerSession, "psess-a"); err != nil {
+		t.Fatalf("reserve a: %v", err)
+	}
+	if _, err := g.reserveSlot(gameServerSession, "psess-b"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("reserve b above -max-players: got %v, want ResourceExhausted", err)
+	}
+}
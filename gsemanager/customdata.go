@@ -0,0 +1,203 @@
+package gsemanager
+
+import (
+	"encoding/json"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"supertuxkart/logger"
+	"sync"
+	"time"
+)
+
+// 自动上报自定义数据的来源
+const (
+	CustomDataSourceNone = ""
+	CustomDataSourceLog  = "log"  // 从游戏日志解析 peer 数
+	CustomDataSourceFile = "file" // 游戏写的统计文件
+	CustomDataSourcePush = "push" // 游戏调用 /v1/custom-data/push
+)
+
+// ParseCustomDataSource returns an error unless s is a known source.
+func ParseCustomDataSource(s string) (string, error) {
+	switch s {
+	case CustomDataSourceNone, CustomDataSourceLog, CustomDataSourceFile, CustomDataSourcePush:
+		return s, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown custom data source %q, expect log, file or push", s)
+}
+
+// CustomData is one ReportCustomData sample. The file source reads it as
+// {"currentCustomCount": 3, "maxCustomCount": 8}.
+type CustomData struct {
+	CurrentCustomCount int32 `json:"currentCustomCount"`
+	MaxCustomCount     int32 `json:"maxCustomCount"`
+}
+
+var (
+	customDataReporterIns  *CustomDataReporter
+	customDataReporterOnce sync.Once
+)
+
+// CustomDataReporter reports the latest sample to the agent, skipping values
+// that were already reported and waiting at least minInterval between calls.
+// A MaxCustomCount <= 0 is replaced by the session's MaxPlayers(). Nothing is
+// reported before Attach hands it the manager of a ready process.
+type CustomDataReporter struct {
+	mu          sync.Mutex
+	source      string // Start 之前为 CustomDataSourceNone
+	gseManager  *GseManager
+	minInterval time.Duration
+	latest      *CustomData
+	reported    *CustomData
+	lastReport  time.Time
+	notify      chan struct{}
+}
+
+func GetCustomDataReporter() *CustomDataReporter {
+	customDataReporterOnce.Do(func() {
+		customDataReporterIns = &CustomDataReporter{
+			notify: make(chan struct{}, 1),
+		}
+	})
+
+	return customDataReporterIns
+}
+
+// Start begins reporting samples from source; updates before Start are kept
+// and sent first. A minInterval <= 0 means one second.
+func (r *CustomDataReporter) Start(source string, minInterval time.Duration) {
+	r.mu.Lock()
+	if r.source != CustomDataSourceNone || source == CustomDataSourceNone {
+		r.mu.Unlock()
+		return
+	}
+	r.source = source
+	r.minInterval = minInterval
+	if r.minInterval <= 0 {
+		r.minInterval = time.Second
+	}
+	r.mu.Unlock()
+
+	go r.run()
+	r.wake()
+}
+
+// Source returns the source passed to Start, CustomDataSourceNone if the
+// reporter is not running.
+func (r *CustomDataReporter) Source() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.source
+}
+
+// Attach sets the process the samples are reported for, once its
+// ProcessReady has succeeded; samples kept until then are sent first.
+func (r *CustomDataReporter) Attach(g *GseManager) {
+	r.mu.Lock()
+	r.gseManager = g
+	r.mu.Unlock()
+
+	r.wake()
+}
+
+// Update records a new sample; it never blocks on the agent.
+func (r *CustomDataReporter) Update(data CustomData) {
+	r.mu.Lock()
+	r.latest = &data
+	r.mu.Unlock()
+
+	r.wake()
+}
+
+func (r *CustomDataReporter) wake() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pending returns the sample to report and the process it is for, or nil
+// if it was already reported or no process is attached yet.
+func (r *CustomDataReporter) pending() (*CustomData, *GseManager) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.latest == nil || r.gseManager == nil {
+		return nil, nil
+	}
+
+	data := *r.latest
+	if data.MaxCustomCount <= 0 {
+		data.MaxCustomCount = int32(r.gseManager.MaxPlayers())
+	}
+	if r.reported != nil && *r.reported == data {
+		return nil, nil
+	}
+	return &data, r.gseManager
+}
+
+func (r *CustomDataReporter) run() {
+	for range r.notify {
+		for {
+			if wait := r.minInterval - time.Since(r.lastReport); wait > 0 {
+				time.Sleep(wait)
+			}
+
+			data, gseManager := r.pending()
+			if data == nil {
+				break
+			}
+
+			r.lastReport = time.Now()
+			if err := gseManager.ReportCustomData(data.CurrentCustomCount, data.MaxCustomCount); err != nil {
+				// 下一轮重试
+				logger.Warn("auto ReportCustomData fail", zap.Error(err))
+				continue
+			}
+
+			r.mu.Lock()
+			r.reported = data
+			r.mu.Unlock()
+		}
+	}
+}
+
+// WatchFile polls path every interval and feeds each sample to Update. A
+// missing or malformed file is logged once until it becomes readable again.
+func (r *CustomDataReporter) WatchFile(path string, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastErr string
+		for ; ; <-ticker.C {
+			data, err := readCustomDataFile(path)
+			if err != nil {
+				if err.Error() != lastErr {
+					logger.Warn("read custom data file fail", zap.String("path", path), zap.Error(err))
+				}
+				lastErr = err.Error()
+				continue
+			}
+
+			lastErr = ""
+			r.Update(*data)
+		}
+	}()
+}
+
+func readCustomDataFile(path string) (*CustomData, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data := new(CustomData)
+	if err := json.Unmarshal(b, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
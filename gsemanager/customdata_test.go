@@ -0,0 +1,87 @@
+package gsemanager
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// reportingSdk records every ReportCustomData call.
+type reportingSdk struct {
+	*LocalSdk
+	reports chan CustomData
+}
+
+func (s *reportingSdk) ReportCustomData(ctx context.Context, currentCustomCount, maxCustomCount int32) error {
+	s.reports <- CustomData{CurrentCustomCount: currentCustomCount, MaxCustomCount: maxCustomCount}
+	return nil
+}
+
+func newReportingTestManager(t *testing.T) (*GseManager, *reportingSdk) {
+	t.Helper()
+
+	sdk := &reportingSdk{LocalSdk: NewLocalSdk("1"), reports: make(chan CustomData, 10)}
+	g := newTestManager(t, func(pid string) Sdk { return sdk })
+	g.SetGameServerSession(&GameServerSession{GameServerSessionId: "gss-1", MaxPlayers: 8})
+	return g, sdk
+}
+
+func TestCustomDataReporterPending(t *testing.T) {
+	g, _ := newReportingTestManager(t)
+	r := &CustomDataReporter{notify: make(chan struct{}, 1)}
+
+	r.Update(CustomData{CurrentCustomCount: 1})
+	if data, _ := r.pending(); data != nil {
+		t.Errorf("pending before Attach = %+v, want nil", data)
+	}
+
+	r.Attach(g)
+	data, gseManager := r.pending()
+	if want := (CustomData{CurrentCustomCount: 1, MaxCustomCount: 8}); data == nil || *data != want || gseManager != g {
+		t.Fatalf("pending = %+v, want %+v with MaxCustomCount from the session", data, want)
+	}
+
+	r.reported = data
+	r.Update(CustomData{CurrentCustomCount: 1, MaxCustomCount: 8})
+	if data, _ := r.pending(); data != nil {
+		t.Errorf("pending after the same sample was reported = %+v, want nil", data)
+	}
+
+	r.Update(CustomData{CurrentCustomCount: 2})
+	if data, _ := r.pending(); data == nil || data.CurrentCustomCount != 2 {
+		t.Errorf("pending after a new sample = %+v, want CurrentCustomCount 2", data)
+	}
+}
+
+func TestCustomDataReporterRateLimit(t *testing.T) {
+	g, sdk := newReportingTestManager(t)
+	r := &CustomDataReporter{notify: make(chan struct{}, 1)}
+	const minInterval = 100 * time.Millisecond
+
+	r.Start(CustomDataSourcePush, minInterval)
+	if got := r.Source(); got != CustomDataSourcePush {
+		t.Errorf("Source = %q, want push", got)
+	}
+	r.Attach(g)
+	r.Update(CustomData{CurrentCustomCount: 1, MaxCustomCount: 4})
+	<-sdk.reports
+	first := time.Now()
+
+	// 同样的值不再上报
+	r.Update(CustomData{CurrentCustomCount: 1, MaxCustomCount: 4})
+	r.Update(CustomData{CurrentCustomCount: 2, MaxCustomCount: 4})
+	r.Update(CustomData{CurrentCustomCount: 3, MaxCustomCount: 4})
+	got := <-sdk.reports
+	if elapsed := time.Since(first); elapsed < minInterval*9/10 {
+		t.Errorf("second report after %v, want at least %v", elapsed, minInterval)
+	}
+	if want := (CustomData{CurrentCustomCount: 3, MaxCustomCount: 4}); got != want {
+		t.Errorf("second report = %+v, want only the latest sample %+v", got, want)
+	}
+
+	select {
+	case extra := <-sdk.reports:
+		t.Errorf("unexpected report %+v", extra)
+	case <-time.After(2 * minInterval):
+	}
+}
@@ -1,211 +1,357 @@
 package gsemanager
 
 import (
-	"context"
-	"fmt"
-	uuid "github.com/satori/go.uuid"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/metadata"
-	"os"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"strconv"
-	"supertuxkart/grpcsdk"
+	"supertuxkart/events"
 	"supertuxkart/logger"
+	"supertuxkart/tracing"
 	"sync"
 )
 
 var (
-	gseManagerIns *gsemanager
-	once          sync.Once
+	// 进程 ProcessReady 之后才登记，在此之前查不到，不会用 wrapper 自己的 pid 建 manager
+	registryMu sync.RWMutex
+	first      *GseManager
+	byGrpcPort = make(map[int]*GseManager)
 )
 
-const (
-	localhost = "127.0.0.1"
-	agentPort = 10001
-)
-
-type gsemanager struct {
+type GseManager struct {
 	pid               string
-	gameServerSession *grpcsdk.GameServerSession
+	mu                sync.RWMutex
+	gameServerSession *GameServerSession
+	sessionEnded      bool // TerminateGameServerSession 已结束当前会话，可以接新会话
 	terminationTime   int64
-	rpcClient         grpcsdk.GseGrpcSdkServiceClient
+	state             LifecycleState
+	stateChanged      chan struct{}
+	clientPort        int
+	grpcPort          int
+	httpPort          int
+	sdk               Sdk
+	playerSessions    *playerSessionCache
+	policy            *policyState
+	capacity          *capacity
+	termination       *termination
+	trace             *tracing.Session
 }
 
-func GetGseManagerByPid(pid int) *gsemanager {
-	once.Do(func() {
-		gseManagerIns = &gsemanager{
-			pid: strconv.Itoa(pid),
-		}
+// NewGseManager returns the manager of a launched game server process.
+// Lookups find it once its ProcessReady has succeeded.
+func NewGseManager(pid int) *GseManager {
+	return newGseManager(pid)
+}
 
-		url := fmt.Sprintf("%s:%d", localhost, agentPort)
+// GetGseManagerByGrpcPort returns the manager whose ProcessReady announced
+// grpcPort; 0 means the first process. Before the first ProcessReady it is
+// Unavailable, and an unknown port is NotFound.
+func GetGseManagerByGrpcPort(grpcPort int) (*GseManager, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
 
-		conn, err := grpc.DialContext(context.Background(), url, grpc.WithInsecure())
-		if err != nil {
-			logger.Fatal("dail to gse fail", zap.String("url", url), zap.Error(err))
+	if grpcPort == 0 {
+		if first == nil {
+			return nil, status.Error(codes.Unavailable, "no game server process is ready yet")
 		}
+		return first, nil
+	}
 
-		gseManagerIns.rpcClient = grpcsdk.NewGseGrpcSdkServiceClient(conn)
-	})
+	g, ok := byGrpcPort[grpcPort]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no game server process is ready on grpc port %d", grpcPort)
+	}
+	return g, nil
+}
 
-	return gseManagerIns
+func newGseManager(pid int) *GseManager {
+	g := &GseManager{
+		pid:            strconv.Itoa(pid),
+		state:          StateStarting,
+		stateChanged:   make(chan struct{}),
+		playerSessions: newPlayerSessionCache(),
+		policy:         newPolicyState(),
+		capacity:       newCapacity(),
+		termination:    &termination{},
+		trace:          tracing.NewSession(),
+	}
+	g.sdk = sdkFor(g.pid)
+	if b, ok := g.sdk.(managerBinder); ok {
+		b.bind(g)
+	}
+	return g
 }
 
-func GetGseManager() *gsemanager {
-	once.Do(func() {
-		gseManagerIns = &gsemanager{
-			pid: strconv.Itoa(os.Getpid()),
-		}
+// errNoGameServerSession is returned by calls that need a session before
+// OnStartGameServerSession has delivered one.
+var errNoGameServerSession = status.Error(codes.FailedPrecondition, "no active game server session")
 
-		url := fmt.Sprintf("%s:%d", localhost, agentPort)
+func (g *GseManager) SetGameServerSession(gameserversession *GameServerSession) {
+	g.replaceSession(gameserversession)
+}
 
-		conn, err := grpc.DialContext(context.Background(), url, grpc.WithInsecure())
-		if err != nil {
-			logger.Fatal("dail to gse fail", zap.String("url", url), zap.Error(err))
-		}
+// sessionSnapshot 是 replaceSession 换掉的会话状态
+type sessionSnapshot struct {
+	gameServerSession *GameServerSession
+	sessionEnded      bool
+	policy            policySnapshot
+	players           map[string]bool
+}
+
+// replaceSession makes gameServerSession current with a fresh policy and
+// player list, and returns what it replaced for restoreSession.
+func (g *GseManager) replaceSession(gameServerSession *GameServerSession) sessionSnapshot {
+	g.mu.Lock()
+	previous := sessionSnapshot{gameServerSession: g.gameServerSession, sessionEnded: g.sessionEnded}
+	g.gameServerSession = gameServerSession
+	g.sessionEnded = false
+	g.mu.Unlock()
+
+	previous.policy = g.policy.reset()
+	previous.players = g.playerSessions.resetPlayers()
+	return previous
+}
 
-		gseManagerIns.rpcClient = grpcsdk.NewGseGrpcSdkServiceClient(conn)
-	})
+func (g *GseManager) restoreSession(previous sessionSnapshot) {
+	g.mu.Lock()
+	g.gameServerSession = previous.gameServerSession
+	g.sessionEnded = previous.sessionEnded
+	g.mu.Unlock()
 
-	return gseManagerIns
+	g.policy.restore(previous.policy)
+	g.playerSessions.restorePlayers(previous.players)
 }
 
-func (g *gsemanager) SetGameServerSession(gameserversession *grpcsdk.GameServerSession) {
-	g.gameServerSession = gameserversession
+// StartGameServerSession makes gameServerSession current and activates it.
+// If activation fails, the previous session, its policy and the players seen
+// in the log are put back, so the agent can retry with the same ID.
+func (g *GseManager) StartGameServerSession(gameServerSession *GameServerSession) error {
+	previous := g.replaceSession(gameServerSession)
+	err := g.ActivateGameServerSession(gameServerSession.GameServerSessionId, gameServerSession.MaxPlayers)
+	if err != nil {
+		g.restoreSession(previous)
+	}
+	return err
 }
 
-func (g *gsemanager) SetTerminationTime(terminationTime int64) {
-	g.terminationTime = terminationTime
+// GetGameServerSession returns the session delivered by OnStartGameServerSession, or nil.
+func (g *GseManager) GetGameServerSession() *GameServerSession {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.gameServerSession
+}
+
+// Trace returns the holder of this process's game server session span.
+func (g *GseManager) Trace() *tracing.Session {
+	return g.trace
 }
 
-func (g *gsemanager) getContext() context.Context {
-	requestId := uuid.NewV4().String()
-	ctx := metadata.AppendToOutgoingContext(context.Background(), "pid", g.pid)
-	return metadata.AppendToOutgoingContext(ctx, "requestId", requestId)
+// GameServerSessionId returns the current session's ID, or "" before the first session.
+func (g *GseManager) GameServerSessionId() string {
+	if gameServerSession := g.GetGameServerSession(); gameServerSession != nil {
+		return gameServerSession.GameServerSessionId
+	}
+	return ""
+}
+
+func (g *GseManager) SetTerminationTime(terminationTime int64) {
+	g.mu.Lock()
+	g.terminationTime = terminationTime
+	g.mu.Unlock()
+
+	g.AdvanceState(StateTerminating)
 }
 
 // 1. ProcessReady
-func (g *gsemanager) ProcessReady(logPath []string, clientPort int32, grpcPort int32) error {
+func (g *GseManager) ProcessReady(logPath []string, clientPort int32, grpcPort int32) error {
 	logger.Info("start to processready", zap.Any("logPath", logPath), zap.Int32("clientPort", clientPort),
 		zap.Int32("grpcPort", grpcPort))
-	pid, _ := strconv.ParseInt(g.pid, 10, 32)
-	req := &grpcsdk.ProcessReadyRequest{
-		LogPathsToUpload: logPath,
-		ClientPort:       clientPort,
-		GrpcPort:         grpcPort,
-		Pid:              int32(pid),
-	}
-
-	_, err := g.rpcClient.ProcessReady(g.getContext(), req)
+	err := g.sdk.Ready(g.trace.Context(), logPath, clientPort, grpcPort)
 	if err != nil {
 		logger.Info("ProcessReady fail", zap.Error(err))
 		return err
 	}
 
 	logger.Info("ProcessReady success")
+	g.markReady(int(clientPort), int(grpcPort))
 	return nil
 }
 
+// GrpcPort returns the wrapper grpc port announced by ProcessReady, 0 before.
+func (g *GseManager) GrpcPort() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.grpcPort
+}
+
+// markReady records the ports of a process its backend now knows about.
+func (g *GseManager) markReady(clientPort, grpcPort int) {
+	g.mu.Lock()
+	g.clientPort = clientPort
+	g.grpcPort = grpcPort
+	g.mu.Unlock()
+
+	// 进程是逐个启动的，第一个登记的就是第一个进程
+	registryMu.Lock()
+	if first == nil {
+		first = g
+	}
+	byGrpcPort[grpcPort] = g
+	registryMu.Unlock()
+	g.AdvanceState(StateProcessReady)
+}
+
 // 2. ActivateGameServerSession
-func (g *gsemanager) ActivateGameServerSession(gameServerSessionId string, maxPlayers int32) error {
+func (g *GseManager) ActivateGameServerSession(gameServerSessionId string, maxPlayers int32) error {
 	logger.Info("start to ActivateGameServerSession", zap.String("gameServerSessionId", gameServerSessionId),
 		zap.Int32("maxPlayers", maxPlayers))
-	req := &grpcsdk.ActivateGameServerSessionRequest{
-		GameServerSessionId: gameServerSessionId,
-		MaxPlayers:          maxPlayers,
-	}
-
-	_, err := g.rpcClient.ActivateGameServerSession(g.getContext(), req)
+	err := g.sdk.ActivateSession(g.trace.Context(), gameServerSessionId, maxPlayers)
 	if err != nil {
 		logger.Error("ActivateGameServerSession fail", zap.Error(err))
 		return err
 	}
 
 	logger.Info("ActivateGameServerSession success")
+	g.AdvanceState(StateSessionActive)
 	return nil
 }
 
 // 3. AcceptPlayerSession
-func (g *gsemanager) AcceptPlayerSession(playerSessionId string) (*grpcsdk.AuxProxyResponse, error) {
+func (g *GseManager) AcceptPlayerSession(playerSessionId string) error {
 	logger.Info("start to AcceptPlayerSession", zap.String("playerSessionId", playerSessionId))
-	req := &grpcsdk.AcceptPlayerSessionRequest{
-		GameServerSessionId: g.gameServerSession.GameServerSessionId,
-		PlayerSessionId:     playerSessionId,
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession == nil {
+		return errNoGameServerSession
+	}
+
+	// 满员直接拒绝，不调用 agent
+	release, err := g.reserveSlot(gameServerSession, playerSessionId)
+	if err != nil {
+		logger.Info("AcceptPlayerSession rejected", zap.String("playerSessionId", playerSessionId), zap.Error(err))
+		return err
 	}
 
-	return g.rpcClient.AcceptPlayerSession(g.getContext(), req)
+	// 先记入缓存再释放占位，中间不会多出一个空位
+	err = g.sdk.AcceptPlayer(g.trace.Context(), gameServerSession.GameServerSessionId, playerSessionId)
+	if err != nil {
+		release()
+		return err
+	}
+	g.playerSessions.accepted(gameServerSession.GameServerSessionId, playerSessionId)
+	release()
+	events.Publish(events.Event{Type: events.PlayerSessionAccepted, GrpcPort: g.GrpcPort(),
+		GameServerSessionId: gameServerSession.GameServerSessionId, PlayerSessionId: playerSessionId})
+	g.applyPlayerSessionPolicy()
+	return nil
 }
 
 // 4. RemovePlayerSession
-func (g *gsemanager) RemovePlayerSession(playerSessionId string) (*grpcsdk.AuxProxyResponse, error) {
+func (g *GseManager) RemovePlayerSession(playerSessionId string) error {
 	logger.Info("start to RemovePlayerSession", zap.String("playerSessionId", playerSessionId))
-	req := &grpcsdk.RemovePlayerSessionRequest{
-		GameServerSessionId: g.gameServerSession.GameServerSessionId,
-		PlayerSessionId:     playerSessionId,
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession == nil {
+		return errNoGameServerSession
 	}
 
-	return g.rpcClient.RemovePlayerSession(g.getContext(), req)
+	err := g.sdk.RemovePlayer(g.trace.Context(), gameServerSession.GameServerSessionId, playerSessionId)
+	if err == nil {
+		g.playerSessions.removed(playerSessionId)
+		events.Publish(events.Event{Type: events.PlayerSessionRemoved, GrpcPort: g.GrpcPort(),
+			GameServerSessionId: gameServerSession.GameServerSessionId, PlayerSessionId: playerSessionId})
+		g.applyPlayerSessionPolicy()
+	}
+	return err
 }
 
 // 5. TerminateGameServerSession
-func (g *gsemanager) TerminateGameServerSession() (*grpcsdk.AuxProxyResponse, error) {
+func (g *GseManager) TerminateGameServerSession() error {
 	logger.Info("start to TerminateGameServerSession")
-	req := &grpcsdk.TerminateGameServerSessionRequest{
-		GameServerSessionId: g.gameServerSession.GameServerSessionId,
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession == nil {
+		return errNoGameServerSession
 	}
 
-	return g.rpcClient.TerminateGameServerSession(g.getContext(), req)
+	err := g.sdk.TerminateSession(g.trace.Context(), gameServerSession.GameServerSessionId)
+	if err == nil {
+		g.endSession(gameServerSession.GameServerSessionId)
+	}
+	return err
 }
 
 // 6. ProcessEnding
-func (g *gsemanager) ProcessEnding() (*grpcsdk.AuxProxyResponse, error) {
+func (g *GseManager) ProcessEnding() error {
 	logger.Info("start to ProcessEnding")
-	pid, _ := strconv.ParseInt(g.pid, 10, 32)
-	req := &grpcsdk.ProcessEndingRequest{
-		Pid: int32(pid),
+	err := g.sdk.End(g.trace.Context())
+	if err == nil {
+		g.AdvanceState(StateEnded)
+		events.Publish(events.Event{Type: events.ProcessEnded, GrpcPort: g.GrpcPort(), GameServerSessionId: g.GameServerSessionId()})
 	}
+	return err
+}
 
-	return g.rpcClient.ProcessEnding(g.getContext(), req)
+// Shutdown sends ProcessEnding unless it has already been sent; the wrapper
+// calls it when the game's log ends.
+func (g *GseManager) Shutdown() error {
+	if g.State() == StateEnded {
+		return nil
+	}
+	return g.ProcessEnding()
 }
 
 // 7. DescribePlayerSessions
-func (g *gsemanager) DescribePlayerSessions(gameServerSessionId, playerId, playerSessionId, playerSessionStatusFilter, nextToken string,
-	limit int32) (*grpcsdk.DescribePlayerSessionsResponse, error) {
+func (g *GseManager) DescribePlayerSessions(gameServerSessionId, playerId, playerSessionId, playerSessionStatusFilter, nextToken string,
+	limit int32) (*PlayerSessionPage, error) {
 	logger.Info("start to DescribePlayerSessions", zap.String("gameServerSessionId", gameServerSessionId),
 		zap.String("playerId", playerId), zap.String("playerSessionId", playerSessionId),
 		zap.String("playerSessionStatusFilter", playerSessionStatusFilter), zap.String("nextToken", nextToken),
 		zap.Int32("limit", limit))
 
-	req := &grpcsdk.DescribePlayerSessionsRequest{
-		GameServerSessionId:       gameServerSessionId,
-		PlayerId:                  playerId,
-		PlayerSessionId:           playerSessionId,
-		PlayerSessionStatusFilter: playerSessionStatusFilter,
-		NextToken:                 nextToken,
-		Limit:                     limit,
+	query := DescribePlayerSessionsQuery{
+		GameServerSessionId: gameServerSessionId,
+		PlayerId:            playerId,
+		PlayerSessionId:     playerSessionId,
+		StatusFilter:        PlayerSessionStatus(playerSessionStatusFilter),
+		PageSize:            limit,
 	}
 
-	return g.rpcClient.DescribePlayerSessions(g.getContext(), req)
+	sessions, next, err := g.sdk.DescribePlayers(g.trace.Context(), query, nextToken)
+	if err != nil {
+		return nil, err
+	}
+	return &PlayerSessionPage{NextToken: next, PlayerSessions: sessions}, nil
 }
 
 // 8. UpdatePlayerSessionCreationPolicy
-func (g *gsemanager) UpdatePlayerSessionCreationPolicy(newPolicy string) (*grpcsdk.AuxProxyResponse, error) {
-	logger.Info("start to UpdatePlayerSessionCreationPolicy", zap.String("newPolicy", newPolicy))
-	req := &grpcsdk.UpdatePlayerSessionCreationPolicyRequest{
-		GameServerSessionId:            g.gameServerSession.GameServerSessionId,
-		NewPlayerSessionCreationPolicy: newPolicy,
+// newPolicy 记为请求的策略；开启自动切换时，满员或 drain 期间仍然发送 DENY_ALL
+func (g *GseManager) UpdatePlayerSessionCreationPolicy(newPolicy PlayerSessionCreationPolicy) error {
+	logger.Info("start to UpdatePlayerSessionCreationPolicy", zap.String("newPolicy", string(newPolicy)))
+	if _, err := ParsePlayerSessionCreationPolicy(string(newPolicy)); err != nil {
+		return err
+	}
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession == nil {
+		return errNoGameServerSession
 	}
 
-	return g.rpcClient.UpdatePlayerSessionCreationPolicy(g.getContext(), req)
+	g.policy.mu.Lock()
+	defer g.policy.mu.Unlock()
+
+	// 等正在发的 SetPolicy 结束，这次的结果要返回给调用方
+	for g.policy.sending {
+		g.policy.idle.Wait()
+	}
+	g.policy.requested = newPolicy
+	if policy, reason := g.effectivePolicy(gameServerSession); policy != newPolicy {
+		logger.Info("requested policy overridden", zap.String("policy", string(policy)), zap.String("reason", reason))
+	}
+	return g.syncPlayerSessionPolicy(gameServerSession, true)
 }
 
 // 9.ReportCustomData
-func (g *gsemanager) ReportCustomData(currentCustomCount, maxCustomCount int32) (*grpcsdk.AuxProxyResponse, error) {
-	logger.Info("start to UpdatePlayerSessionCreationPolicy", zap.Int32("currentCustomCount", currentCustomCount),
+func (g *GseManager) ReportCustomData(currentCustomCount, maxCustomCount int32) error {
+	logger.Info("start to ReportCustomData", zap.Int32("currentCustomCount", currentCustomCount),
 		zap.Int32("maxCustomCount", maxCustomCount))
-	req := &grpcsdk.ReportCustomDataRequest{
-		CurrentCustomCount: currentCustomCount,
-		MaxCustomCount:     maxCustomCount,
-	}
-
-	return g.rpcClient.ReportCustomData(g.getContext(), req)
+	return g.sdk.ReportCustomData(g.trace.Context(), currentCustomCount, maxCustomCount)
 }
@@ -0,0 +1,74 @@
+package gsemanager
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// activateFailSdk fails ActivateSession while fail is set.
+type activateFailSdk struct {
+	*LocalSdk
+	fail bool
+}
+
+func (s *activateFailSdk) ActivateSession(ctx context.Context, gameServerSessionId string, maxPlayers int32) error {
+	if s.fail {
+		return status.Error(codes.Unavailable, "agent unavailable")
+	}
+	return s.LocalSdk.ActivateSession(ctx, gameServerSessionId, maxPlayers)
+}
+
+func TestStartGameServerSessionAfterTermination(t *testing.T) {
+	sdk := &activateFailSdk{LocalSdk: NewLocalSdk("1")}
+	g := newTestManager(t, func(pid string) Sdk { return sdk })
+
+	if err := g.StartGameServerSession(&GameServerSession{GameServerSessionId: "gss-1", MaxPlayers: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if !g.SessionLive() {
+		t.Fatal("gss-1 is not live after activation")
+	}
+
+	g.SetDraining(true)
+	g.PlayerConnected("alice")
+	if err := g.TerminateGameServerSession(); err != nil {
+		t.Fatal(err)
+	}
+	if g.SessionLive() {
+		t.Fatal("gss-1 is still live after TerminateGameServerSession")
+	}
+
+	// 激活失败时恢复 gss-1 的会话、策略和日志里的玩家
+	sdk.fail = true
+	if err := g.StartGameServerSession(&GameServerSession{GameServerSessionId: "gss-2", MaxPlayers: 4}); err == nil {
+		t.Fatal("StartGameServerSession succeeded with a failing agent")
+	}
+	if got := g.GameServerSessionId(); got != "gss-1" {
+		t.Errorf("session after the failed activation = %s, want gss-1", got)
+	}
+	if g.SessionLive() {
+		t.Error("ended gss-1 became live again after the failed activation")
+	}
+	if st := g.Status(); !st.Draining || st.Policy != PolicyDenyAll {
+		t.Errorf("policy after the failed activation = %s draining %v, want DENY_ALL draining", st.Policy, st.Draining)
+	}
+	g.playerSessions.mu.RLock()
+	online := g.playerSessions.players["alice"]
+	g.playerSessions.mu.RUnlock()
+	if !online {
+		t.Error("players seen in the log were not restored")
+	}
+
+	sdk.fail = false
+	if err := g.StartGameServerSession(&GameServerSession{GameServerSessionId: "gss-2", MaxPlayers: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.GameServerSessionId(); got != "gss-2" || !g.SessionLive() {
+		t.Errorf("session = %s live %v, want gss-2 live", got, g.SessionLive())
+	}
+	if st := g.Status(); st.Draining || st.Policy != PolicyAcceptAll {
+		t.Errorf("policy of gss-2 = %s draining %v, want ACCEPT_ALL", st.Policy, st.Draining)
+	}
+}
@@ -0,0 +1,185 @@
+package gsemanager
+
+import (
+	"context"
+	"fmt"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"supertuxkart/grpcsdk"
+	"supertuxkart/logger"
+	"supertuxkart/tracing"
+)
+
+const (
+	localhost = "127.0.0.1"
+	agentPort = 10001
+)
+
+// gseSdk 通过 gRPC 调用本机的 GSE agent
+type gseSdk struct {
+	pid       string
+	rpcClient grpcsdk.GseGrpcSdkServiceClient
+}
+
+func newGseSdk(pid string) *gseSdk {
+	url := fmt.Sprintf("%s:%d", localhost, agentPort)
+
+	conn, err := grpc.DialContext(context.Background(), url, grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()))
+	if err != nil {
+		logger.Fatal("dail to gse fail", zap.String("url", url), zap.Error(err))
+	}
+
+	return &gseSdk{
+		pid:       pid,
+		rpcClient: grpcsdk.NewGseGrpcSdkServiceClient(conn),
+	}
+}
+
+// withMetadata adds the pid and requestId metadata the agent expects to ctx.
+func (s *gseSdk) withMetadata(ctx context.Context) context.Context {
+	requestId := uuid.NewV4().String()
+	ctx = metadata.AppendToOutgoingContext(ctx, "pid", s.pid)
+	return metadata.AppendToOutgoingContext(ctx, "requestId", requestId)
+}
+
+func (s *gseSdk) pidInt32() int32 {
+	pid, _ := strconv.ParseInt(s.pid, 10, 32)
+	return int32(pid)
+}
+
+func (s *gseSdk) Ready(ctx context.Context, logPaths []string, clientPort, grpcPort int32) error {
+	_, err := s.rpcClient.ProcessReady(s.withMetadata(ctx), &grpcsdk.ProcessReadyRequest{
+		LogPathsToUpload: logPaths,
+		ClientPort:       clientPort,
+		GrpcPort:         grpcPort,
+		Pid:              s.pidInt32(),
+	})
+	return err
+}
+
+func (s *gseSdk) ActivateSession(ctx context.Context, gameServerSessionId string, maxPlayers int32) error {
+	_, err := s.rpcClient.ActivateGameServerSession(s.withMetadata(ctx), &grpcsdk.ActivateGameServerSessionRequest{
+		GameServerSessionId: gameServerSessionId,
+		MaxPlayers:          maxPlayers,
+	})
+	return err
+}
+
+func (s *gseSdk) AcceptPlayer(ctx context.Context, gameServerSessionId, playerSessionId string) error {
+	_, err := s.rpcClient.AcceptPlayerSession(s.withMetadata(ctx), &grpcsdk.AcceptPlayerSessionRequest{
+		GameServerSessionId: gameServerSessionId,
+		PlayerSessionId:     playerSessionId,
+	})
+	return err
+}
+
+func (s *gseSdk) RemovePlayer(ctx context.Context, gameServerSessionId, playerSessionId string) error {
+	_, err := s.rpcClient.RemovePlayerSession(s.withMetadata(ctx), &grpcsdk.RemovePlayerSessionRequest{
+		GameServerSessionId: gameServerSessionId,
+		PlayerSessionId:     playerSessionId,
+	})
+	return err
+}
+
+func (s *gseSdk) TerminateSession(ctx context.Context, gameServerSessionId string) error {
+	_, err := s.rpcClient.TerminateGameServerSession(s.withMetadata(ctx), &grpcsdk.TerminateGameServerSessionRequest{
+		GameServerSessionId: gameServerSessionId,
+	})
+	return err
+}
+
+func (s *gseSdk) End(ctx context.Context) error {
+	_, err := s.rpcClient.ProcessEnding(s.withMetadata(ctx), &grpcsdk.ProcessEndingRequest{
+		Pid: s.pidInt32(),
+	})
+	return err
+}
+
+func (s *gseSdk) ReportCustomData(ctx context.Context, currentCustomCount, maxCustomCount int32) error {
+	_, err := s.rpcClient.ReportCustomData(s.withMetadata(ctx), &grpcsdk.ReportCustomDataRequest{
+		CurrentCustomCount: currentCustomCount,
+		MaxCustomCount:     maxCustomCount,
+	})
+	return err
+}
+
+func (s *gseSdk) DescribePlayers(ctx context.Context, query DescribePlayerSessionsQuery,
+	nextToken string) ([]*PlayerSession, string, error) {
+	resp, err := s.rpcClient.DescribePlayerSessions(s.withMetadata(ctx), &grpcsdk.DescribePlayerSessionsRequest{
+		GameServerSessionId:       query.GameServerSessionId,
+		PlayerId:                  query.PlayerId,
+		PlayerSessionId:           query.PlayerSessionId,
+		PlayerSessionStatusFilter: string(query.StatusFilter),
+		NextToken:                 nextToken,
+		Limit:                     query.PageSize,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	sessions := make([]*PlayerSession, 0, len(resp.PlayerSessions))
+	for _, ps := range resp.PlayerSessions {
+		sessions = append(sessions, &PlayerSession{
+			PlayerSessionId:     ps.PlayerSessionId,
+			PlayerId:            ps.PlayerId,
+			GameServerSessionId: ps.GameServerSessionId,
+			FleetId:             ps.FleetId,
+			IpAddress:           ps.IpAddress,
+			Status:              PlayerSessionStatus(ps.Status),
+			CreationTime:        ps.CreationTime,
+			TerminationTime:     ps.TerminationTime,
+			Port:                ps.Port,
+			PlayerData:          ps.PlayerData,
+			DnsName:             ps.DnsName,
+		})
+	}
+	return sessions, resp.NextToken, nil
+}
+
+func (s *gseSdk) SetPolicy(ctx context.Context, gameServerSessionId string, policy PlayerSessionCreationPolicy) error {
+	_, err := s.rpcClient.UpdatePlayerSessionCreationPolicy(s.withMetadata(ctx),
+		&grpcsdk.UpdatePlayerSessionCreationPolicyRequest{
+			GameServerSessionId:            gameServerSessionId,
+			NewPlayerSessionCreationPolicy: string(policy),
+		})
+	return err
+}
+
+// GameServerSessionFromGse converts the session the agent sends with
+// OnStartGameServerSession; nil stays nil.
+func GameServerSessionFromGse(gss *grpcsdk.GameServerSession) *GameServerSession {
+	if gss == nil {
+		return nil
+	}
+
+	gameServerSession := &GameServerSession{
+		GameServerSessionId:   gss.GameServerSessionId,
+		FleetId:               gss.FleetId,
+		Name:                  gss.Name,
+		MaxPlayers:            gss.MaxPlayers,
+		Joinable:              gss.Joinable,
+		Port:                  gss.Port,
+		IpAddress:             gss.IpAddress,
+		GameServerSessionData: gss.GameServerSessionData,
+		MatchmakerData:        gss.MatchmakerData,
+		DnsName:               gss.DnsName,
+	}
+	for _, p := range gss.GameProperties {
+		gameServerSession.GameProperties = append(gameServerSession.GameProperties,
+			&GameProperty{Key: p.Key, Value: p.Value})
+	}
+	return gameServerSession
+}
+
+// GSE 通过玩家会话跟踪玩家，日志里的玩家进出不用上报
+func (s *gseSdk) PlayerConnected(ctx context.Context, playerId string) error {
+	return nil
+}
+
+func (s *gseSdk) PlayerDisconnected(ctx context.Context, playerId string) error {
+	return nil
+}
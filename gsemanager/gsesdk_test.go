@@ -0,0 +1,43 @@
+package gsemanager
+
+import (
+	"reflect"
+	"supertuxkart/grpcsdk"
+	"testing"
+)
+
+func TestGameServerSessionFromGse(t *testing.T) {
+	if got := GameServerSessionFromGse(nil); got != nil {
+		t.Errorf("GameServerSessionFromGse(nil) = %+v, want nil", got)
+	}
+
+	got := GameServerSessionFromGse(&grpcsdk.GameServerSession{
+		GameServerSessionId:   "gss-1",
+		FleetId:               "fleet-1",
+		Name:                  "stk",
+		MaxPlayers:            8,
+		Joinable:              true,
+		GameProperties:        []*grpcsdk.GameProperty{{Key: "mode", Value: "ffa"}},
+		Port:                  2759,
+		IpAddress:             "10.0.0.1",
+		GameServerSessionData: "data",
+		MatchmakerData:        "{}",
+		DnsName:               "gss-1.example",
+	})
+	want := &GameServerSession{
+		GameServerSessionId:   "gss-1",
+		FleetId:               "fleet-1",
+		Name:                  "stk",
+		MaxPlayers:            8,
+		Joinable:              true,
+		GameProperties:        []*GameProperty{{Key: "mode", Value: "ffa"}},
+		Port:                  2759,
+		IpAddress:             "10.0.0.1",
+		GameServerSessionData: "data",
+		MatchmakerData:        "{}",
+		DnsName:               "gss-1.example",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GameServerSessionFromGse = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package gsemanager
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"supertuxkart/logger"
+	"sync"
+	"time"
+)
+
+// LocalSdk is an in-memory Sdk for runs without any platform. Every call
+// succeeds and is logged; accepted player sessions are kept so that
+// DescribePlayers and the reconcile loop see them. There is no matchmaker
+// creating RESERVED sessions, so AcceptPlayer creates an ACTIVE one whose
+// PlayerId is the playerSessionId.
+type LocalSdk struct {
+	pid string
+
+	mu             sync.Mutex
+	playerSessions []*PlayerSession
+	policy         PlayerSessionCreationPolicy
+}
+
+func NewLocalSdk(pid string) *LocalSdk {
+	return &LocalSdk{
+		pid:    pid,
+		policy: PolicyAcceptAll,
+	}
+}
+
+func (s *LocalSdk) Ready(ctx context.Context, logPaths []string, clientPort, grpcPort int32) error {
+	logger.Info("local sdk ready", zap.String("pid", s.pid), zap.Int32("clientPort", clientPort),
+		zap.Int32("grpcPort", grpcPort))
+	return nil
+}
+
+func (s *LocalSdk) ActivateSession(ctx context.Context, gameServerSessionId string, maxPlayers int32) error {
+	logger.Info("local sdk activate session", zap.String("gameServerSessionId", gameServerSessionId))
+	return nil
+}
+
+func (s *LocalSdk) find(playerSessionId string) *PlayerSession {
+	for _, ps := range s.playerSessions {
+		if ps.PlayerSessionId == playerSessionId {
+			return ps
+		}
+	}
+	return nil
+}
+
+func (s *LocalSdk) AcceptPlayer(ctx context.Context, gameServerSessionId, playerSessionId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ps := s.find(playerSessionId); ps != nil {
+		if ps.Status != PlayerSessionActive {
+			return status.Errorf(codes.FailedPrecondition, "player session %s is %s", playerSessionId, ps.Status)
+		}
+		return nil
+	}
+	if s.policy == PolicyDenyAll {
+		return status.Errorf(codes.FailedPrecondition, "player session creation policy is %s", s.policy)
+	}
+
+	s.playerSessions = append(s.playerSessions, &PlayerSession{
+		PlayerSessionId:     playerSessionId,
+		PlayerId:            playerSessionId,
+		GameServerSessionId: gameServerSessionId,
+		Status:              PlayerSessionActive,
+		CreationTime:        time.Now().Unix(),
+	})
+	return nil
+}
+
+func (s *LocalSdk) RemovePlayer(ctx context.Context, gameServerSessionId, playerSessionId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ps := s.find(playerSessionId)
+	if ps == nil {
+		return status.Errorf(codes.NotFound, "player session %s not found", playerSessionId)
+	}
+	ps.Status = PlayerSessionCompleted
+	ps.TerminationTime = time.Now().Unix()
+	return nil
+}
+
+func (s *LocalSdk) TerminateSession(ctx context.Context, gameServerSessionId string) error {
+	logger.Info("local sdk terminate session", zap.String("gameServerSessionId", gameServerSessionId))
+	return nil
+}
+
+func (s *LocalSdk) End(ctx context.Context) error {
+	logger.Info("local sdk process ending", zap.String("pid", s.pid))
+	return nil
+}
+
+func (s *LocalSdk) ReportCustomData(ctx context.Context, currentCustomCount, maxCustomCount int32) error {
+	logger.Info("local sdk custom data", zap.Int32("currentCustomCount", currentCustomCount),
+		zap.Int32("maxCustomCount", maxCustomCount))
+	return nil
+}
+
+// DescribePlayers pages through the accepted sessions; the token is the
+// index of the next one.
+func (s *LocalSdk) DescribePlayers(ctx context.Context, query DescribePlayerSessionsQuery,
+	nextToken string) ([]*PlayerSession, string, error) {
+	start := 0
+	if nextToken != "" {
+		var err error
+		if start, err = strconv.Atoi(nextToken); err != nil || start < 0 {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid nextToken %q", nextToken)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var page []*PlayerSession
+	for i := start; i < len(s.playerSessions); i++ {
+		ps := s.playerSessions[i]
+		if (query.GameServerSessionId != "" && ps.GameServerSessionId != query.GameServerSessionId) ||
+			(query.PlayerId != "" && ps.PlayerId != query.PlayerId) ||
+			(query.PlayerSessionId != "" && ps.PlayerSessionId != query.PlayerSessionId) ||
+			(query.StatusFilter != "" && ps.Status != query.StatusFilter) {
+			continue
+		}
+		if query.PageSize > 0 && len(page) == int(query.PageSize) {
+			return page, strconv.Itoa(i), nil
+		}
+		copied := *ps
+		page = append(page, &copied)
+	}
+	return page, "", nil
+}
+
+func (s *LocalSdk) SetPolicy(ctx context.Context, gameServerSessionId string, policy PlayerSessionCreationPolicy) error {
+	s.mu.Lock()
+	s.policy = policy
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *LocalSdk) PlayerConnected(ctx context.Context, playerId string) error {
+	return nil
+}
+
+func (s *LocalSdk) PlayerDisconnected(ctx context.Context, playerId string) error {
+	return nil
+}
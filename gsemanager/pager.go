@@ -0,0 +1,109 @@
+package gsemanager
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PlayerSessionStatus 玩家会话状态，与 agent 返回的 PlayerSession.Status 一致
+type PlayerSessionStatus string
+
+const (
+	PlayerSessionReserved  PlayerSessionStatus = "RESERVED"
+	PlayerSessionActive    PlayerSessionStatus = "ACTIVE"
+	PlayerSessionCompleted PlayerSessionStatus = "COMPLETED"
+	PlayerSessionTimedOut  PlayerSessionStatus = "TIMEDOUT"
+)
+
+// ParsePlayerSessionStatus validates a status filter; the empty string means
+// no filter.
+func ParsePlayerSessionStatus(s string) (PlayerSessionStatus, error) {
+	switch st := PlayerSessionStatus(s); st {
+	case "", PlayerSessionReserved, PlayerSessionActive, PlayerSessionCompleted, PlayerSessionTimedOut:
+		return st, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument,
+		"invalid player session status %q, expect RESERVED, ACTIVE, COMPLETED or TIMEDOUT", s)
+}
+
+// DefaultPageSize DescribePlayerSessions 每页默认数量
+const DefaultPageSize = 50
+
+// DescribePlayerSessionsQuery selects the player sessions a pager walks.
+type DescribePlayerSessionsQuery struct {
+	GameServerSessionId string
+	PlayerId            string
+	PlayerSessionId     string
+	StatusFilter        PlayerSessionStatus
+	// PageSize is the limit sent per call, DefaultPageSize when zero.
+	PageSize int32
+}
+
+// PlayerSessionPager walks DescribePlayerSessions page by page following
+// NextToken:
+//
+//	pager := g.NewPlayerSessionPager(query)
+//	for pager.HasNext() {
+//		page, err := pager.Next(ctx)
+//		...
+//	}
+type PlayerSessionPager struct {
+	g         *GseManager
+	query     DescribePlayerSessionsQuery
+	nextToken string
+	done      bool
+}
+
+func (g *GseManager) NewPlayerSessionPager(query DescribePlayerSessionsQuery) *PlayerSessionPager {
+	if query.PageSize <= 0 {
+		query.PageSize = DefaultPageSize
+	}
+
+	return &PlayerSessionPager{
+		g:     g,
+		query: query,
+	}
+}
+
+func (p *PlayerSessionPager) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page. It stops early with ctx's error when ctx is
+// done; after an error HasNext still reports true so the call can be retried.
+func (p *PlayerSessionPager) Next(ctx context.Context) ([]*PlayerSession, error) {
+	if p.done {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	sessions, nextToken, err := p.g.sdk.DescribePlayers(ctx, p.query, p.nextToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// 没有 NextToken，或者 agent 返回了同一个 token，都视为最后一页
+	if nextToken == "" || nextToken == p.nextToken {
+		p.done = true
+	}
+	p.nextToken = nextToken
+	return sessions, nil
+}
+
+// AllPlayerSessions collects every page of query.
+func (g *GseManager) AllPlayerSessions(ctx context.Context, query DescribePlayerSessionsQuery) ([]*PlayerSession, error) {
+	var sessions []*PlayerSession
+
+	pager := g.NewPlayerSessionPager(query)
+	for pager.HasNext() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, page...)
+	}
+	return sessions, nil
+}
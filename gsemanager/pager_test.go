@@ -0,0 +1,136 @@
+package gsemanager
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// pagedSdk answers DescribePlayers from pages keyed by the token asked for.
+type pagedSdk struct {
+	*LocalSdk
+	pages  map[string]pagedResponse
+	tokens []string
+}
+
+type pagedResponse struct {
+	sessions  []string
+	nextToken string
+	err       error
+}
+
+func (s *pagedSdk) DescribePlayers(ctx context.Context, query DescribePlayerSessionsQuery,
+	nextToken string) ([]*PlayerSession, string, error) {
+	s.tokens = append(s.tokens, nextToken)
+
+	resp := s.pages[nextToken]
+	if resp.err != nil {
+		return nil, "", resp.err
+	}
+	var sessions []*PlayerSession
+	for _, id := range resp.sessions {
+		sessions = append(sessions, &PlayerSession{PlayerSessionId: id})
+	}
+	return sessions, resp.nextToken, nil
+}
+
+func newPagedTestManager(t *testing.T, pages map[string]pagedResponse) (*GseManager, *pagedSdk) {
+	t.Helper()
+
+	sdk := &pagedSdk{pages: pages}
+	g := newTestManager(t, func(pid string) Sdk {
+		sdk.LocalSdk = NewLocalSdk(pid)
+		return sdk
+	})
+	return g, sdk
+}
+
+func sessionIds(sessions []*PlayerSession) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, ps := range sessions {
+		ids = append(ids, ps.PlayerSessionId)
+	}
+	return ids
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPlayerSessionPagerFollowsNextToken(t *testing.T) {
+	g, sdk := newPagedTestManager(t, map[string]pagedResponse{
+		"":   {sessions: []string{"a", "b"}, nextToken: "t1"},
+		"t1": {sessions: []string{"c"}, nextToken: "t2"},
+		"t2": {sessions: []string{"d"}},
+	})
+
+	sessions, err := g.AllPlayerSessions(context.Background(), DescribePlayerSessionsQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sessionIds(sessions), []string{"a", "b", "c", "d"}; !equalStrings(got, want) {
+		t.Errorf("sessions = %v, want %v", got, want)
+	}
+	if want := []string{"", "t1", "t2"}; !equalStrings(sdk.tokens, want) {
+		t.Errorf("tokens asked for = %q, want %q", sdk.tokens, want)
+	}
+}
+
+func TestPlayerSessionPagerStopsOnRepeatedToken(t *testing.T) {
+	// agent 一直返回同一个 token 时不能死循环
+	g, sdk := newPagedTestManager(t, map[string]pagedResponse{
+		"":   {sessions: []string{"a"}, nextToken: "t1"},
+		"t1": {sessions: []string{"b"}, nextToken: "t1"},
+	})
+
+	sessions, err := g.AllPlayerSessions(context.Background(), DescribePlayerSessionsQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sessionIds(sessions), []string{"a", "b"}; !equalStrings(got, want) {
+		t.Errorf("sessions = %v, want %v", got, want)
+	}
+	if len(sdk.tokens) != 2 {
+		t.Errorf("DescribePlayers called %d times, want 2", len(sdk.tokens))
+	}
+}
+
+func TestPlayerSessionPagerErrors(t *testing.T) {
+	g, _ := newPagedTestManager(t, map[string]pagedResponse{
+		"": {err: status.Error(codes.Unavailable, "agent down")},
+	})
+
+	pager := g.NewPlayerSessionPager(DescribePlayerSessionsQuery{})
+	if _, err := pager.Next(context.Background()); status.Code(err) != codes.Unavailable {
+		t.Errorf("Next: got %v, want Unavailable", err)
+	}
+	if !pager.HasNext() {
+		t.Error("HasNext() = false after an error, want true so the page can be retried")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := pager.Next(ctx); status.Code(err) != codes.Canceled {
+		t.Errorf("Next with a canceled context: got %v, want Canceled", err)
+	}
+}
+
+func TestPlayerSessionPagerPageSize(t *testing.T) {
+	g, _ := newPagedTestManager(t, nil)
+
+	if got := g.NewPlayerSessionPager(DescribePlayerSessionsQuery{}).query.PageSize; got != DefaultPageSize {
+		t.Errorf("default PageSize = %d, want %d", got, DefaultPageSize)
+	}
+	if got := g.NewPlayerSessionPager(DescribePlayerSessionsQuery{PageSize: 7}).query.PageSize; got != 7 {
+		t.Errorf("PageSize = %d, want 7", got)
+	}
+}
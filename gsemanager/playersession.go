@@ -0,0 +1,83 @@
+package gsemanager
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"regexp"
+	"sync"
+)
+
+const (
+	// MaxBatchSize 单次批量接受/移除玩家会话的上限
+	MaxBatchSize = 100
+	// DefaultBatchParallelism 批量调用 agent 时的并发数
+	DefaultBatchParallelism = 4
+)
+
+var playerSessionIdPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]{0,127}$`)
+
+// ValidatePlayerSessionId returns an InvalidArgument status error when id is
+// not a plausible player session ID.
+func ValidatePlayerSessionId(id string) error {
+	if id == "" {
+		return status.Error(codes.InvalidArgument, "playerSessionId cant be empty")
+	}
+	if !playerSessionIdPattern.MatchString(id) {
+		return status.Errorf(codes.InvalidArgument, "invalid playerSessionId %q", id)
+	}
+	return nil
+}
+
+// PlayerSessionResult is the outcome for one ID of a batch call; Err is nil
+// on success.
+type PlayerSessionResult struct {
+	PlayerSessionId string
+	Err             error
+}
+
+// AcceptPlayerSessions accepts each player session, at most parallelism at a
+// time, and returns one result per ID in the order given.
+func (g *GseManager) AcceptPlayerSessions(playerSessionIds []string, parallelism int) []PlayerSessionResult {
+	return g.batchPlayerSessions(playerSessionIds, parallelism, g.AcceptPlayerSession)
+}
+
+// RemovePlayerSessions is the batch counterpart of RemovePlayerSession.
+func (g *GseManager) RemovePlayerSessions(playerSessionIds []string, parallelism int) []PlayerSessionResult {
+	return g.batchPlayerSessions(playerSessionIds, parallelism, g.RemovePlayerSession)
+}
+
+func (g *GseManager) batchPlayerSessions(playerSessionIds []string, parallelism int, call func(id string) error) []PlayerSessionResult {
+	if parallelism <= 0 {
+		parallelism = DefaultBatchParallelism
+	}
+
+	results := make([]PlayerSessionResult, len(playerSessionIds))
+	seen := make(map[string]bool, len(playerSessionIds))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i, id := range playerSessionIds {
+		results[i].PlayerSessionId = id
+
+		if err := ValidatePlayerSessionId(id); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if seen[id] {
+			results[i].Err = status.Errorf(codes.InvalidArgument, "duplicate playerSessionId %q", id)
+			continue
+		}
+		seen[id] = true
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Err = call(id)
+		}(i, id)
+	}
+
+	wg.Wait()
+	return results
+}
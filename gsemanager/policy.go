@@ -0,0 +1,195 @@
+package gsemanager
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"supertuxkart/events"
+	"supertuxkart/logger"
+	"sync"
+)
+
+// PlayerSessionCreationPolicy 游戏会话是否接受新的玩家会话
+type PlayerSessionCreationPolicy string
+
+const (
+	PolicyAcceptAll PlayerSessionCreationPolicy = "ACCEPT_ALL"
+	PolicyDenyAll   PlayerSessionCreationPolicy = "DENY_ALL"
+)
+
+// ParsePlayerSessionCreationPolicy returns an InvalidArgument status error
+// unless s is ACCEPT_ALL or DENY_ALL.
+func ParsePlayerSessionCreationPolicy(s string) (PlayerSessionCreationPolicy, error) {
+	switch p := PlayerSessionCreationPolicy(s); p {
+	case PolicyAcceptAll, PolicyDenyAll:
+		return p, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument,
+		"invalid player session creation policy %q, expect ACCEPT_ALL or DENY_ALL", s)
+}
+
+// policyState tracks the policy asked for through the API (requested) and the
+// one last acknowledged by the agent (current). With auto switching on, the
+// wrapper sends DENY_ALL while the session is full (see MaxPlayers) or
+// draining and goes back to requested once that no longer holds.
+type policyState struct {
+	mu        sync.Mutex
+	auto      bool
+	draining  bool
+	requested PlayerSessionCreationPolicy
+	current   PlayerSessionCreationPolicy
+
+	// SetPolicy 调用期间不持有 mu，sending 保证同时只有一个在发；
+	// 期间有变化就置 pending，由正在发的一方重新计算
+	sending bool
+	pending bool
+	idle    *sync.Cond
+}
+
+func newPolicyState() *policyState {
+	p := &policyState{
+		auto:      true,
+		requested: PolicyAcceptAll,
+		current:   PolicyAcceptAll,
+	}
+	p.idle = sync.NewCond(&p.mu)
+	return p
+}
+
+// reset 新的游戏会话默认 ACCEPT_ALL
+// policySnapshot 是 reset 之前的策略状态，激活失败时用 restore 恢复
+type policySnapshot struct {
+	draining  bool
+	requested PlayerSessionCreationPolicy
+	current   PlayerSessionCreationPolicy
+}
+
+// reset starts a new session with ACCEPT_ALL and returns the previous state.
+func (p *policyState) reset() policySnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous := policySnapshot{draining: p.draining, requested: p.requested, current: p.current}
+	p.draining = false
+	p.requested = PolicyAcceptAll
+	p.current = PolicyAcceptAll
+	return previous
+}
+
+func (p *policyState) restore(previous policySnapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.draining = previous.draining
+	p.requested = previous.requested
+	p.current = previous.current
+}
+
+// SetAutoPlayerSessionPolicy turns automatic policy switching on or off.
+func (g *GseManager) SetAutoPlayerSessionPolicy(auto bool) {
+	g.policy.mu.Lock()
+	g.policy.auto = auto
+	g.policy.mu.Unlock()
+
+	g.applyPlayerSessionPolicy()
+}
+
+// SetDraining marks the session as draining; no new player sessions are
+// accepted while it is set.
+func (g *GseManager) SetDraining(draining bool) {
+	g.policy.mu.Lock()
+	g.policy.draining = draining
+	g.policy.mu.Unlock()
+
+	g.applyPlayerSessionPolicy()
+}
+
+// PlayerSessionCreationPolicy returns the policy last acknowledged by the agent.
+func (g *GseManager) PlayerSessionCreationPolicy() PlayerSessionCreationPolicy {
+	g.policy.mu.Lock()
+	defer g.policy.mu.Unlock()
+
+	return g.policy.current
+}
+
+// effectivePolicy must be called with g.policy.mu held.
+func (g *GseManager) effectivePolicy(gameServerSession *GameServerSession) (PlayerSessionCreationPolicy, string) {
+	p := g.policy
+	if !p.auto {
+		return p.requested, "requested"
+	}
+	if p.draining {
+		return PolicyDenyAll, "draining"
+	}
+
+	maxPlayers := g.maxPlayersOf(gameServerSession)
+	if maxPlayers > 0 && g.playerSessions.occupied(gameServerSession.GameServerSessionId) >= maxPlayers {
+		return PolicyDenyAll, "full"
+	}
+	return p.requested, "requested"
+}
+
+// applyPlayerSessionPolicy sends the effective policy to the agent when it
+// differs from the current one. If another call is already talking to the
+// agent, it leaves the new state to that call and returns at once.
+func (g *GseManager) applyPlayerSessionPolicy() {
+	gameServerSession := g.GetGameServerSession()
+	if gameServerSession == nil {
+		return
+	}
+
+	g.policy.mu.Lock()
+	defer g.policy.mu.Unlock()
+
+	if g.policy.sending {
+		g.policy.pending = true
+		return
+	}
+	if err := g.syncPlayerSessionPolicy(gameServerSession, false); err != nil {
+		logger.Warn("switch player session creation policy fail", zap.Error(err))
+	}
+}
+
+// syncPlayerSessionPolicy sends the effective policy, and sends again while
+// it changed during the call, returning the error of the first send. always
+// sends the first time even when it equals the current policy.
+//
+// It must be called with g.policy.mu held and no send in progress. The lock
+// is released around each SetPolicy, so Status and accepts never wait for
+// the agent, and is held again on return.
+func (g *GseManager) syncPlayerSessionPolicy(gameServerSession *GameServerSession, always bool) error {
+	p := g.policy
+	p.sending = true
+	defer func() {
+		p.sending = false
+		p.idle.Broadcast()
+	}()
+
+	var firstErr error
+	for first := true; first || p.pending; first = false {
+		p.pending = false
+		policy, reason := g.effectivePolicy(gameServerSession)
+		if policy == p.current && !(always && first) {
+			continue
+		}
+
+		from := p.current
+		p.mu.Unlock()
+		logger.Info("switch player session creation policy", zap.String("from", string(from)),
+			zap.String("to", string(policy)), zap.String("reason", reason))
+		err := g.sdk.SetPolicy(g.trace.Context(), gameServerSession.GameServerSessionId, policy)
+		p.mu.Lock()
+
+		switch {
+		case err != nil && first:
+			firstErr = err
+		case err != nil:
+			logger.Warn("switch player session creation policy fail", zap.Error(err))
+		case p.current != policy:
+			p.current = policy
+			events.Publish(events.Event{Type: events.PolicyChanged, GrpcPort: g.GrpcPort(),
+				GameServerSessionId: gameServerSession.GameServerSessionId, Policy: string(policy)})
+		}
+	}
+	return firstErr
+}
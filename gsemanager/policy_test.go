@@ -0,0 +1,127 @@
+package gsemanager
+
+import (
+	"context"
+	"testing"
+)
+
+// newTestManager returns a manager whose Sdk comes from factory, restoring
+// the default factory afterwards.
+func newTestManager(t *testing.T, factory func(pid string) Sdk) *GseManager {
+	t.Helper()
+
+	sdkMu.RLock()
+	previous := newSdk
+	sdkMu.RUnlock()
+	SetSdkFactory(factory)
+	t.Cleanup(func() { SetSdkFactory(previous) })
+
+	return NewGseManager(1)
+}
+
+func newLocalTestManager(t *testing.T, maxPlayers int32) (*GseManager, *GameServerSession) {
+	t.Helper()
+
+	g := newTestManager(t, func(pid string) Sdk { return NewLocalSdk(pid) })
+	gameServerSession := &GameServerSession{GameServerSessionId: "gss-1", MaxPlayers: maxPlayers}
+	g.SetGameServerSession(gameServerSession)
+	return g, gameServerSession
+}
+
+func TestEffectivePolicy(t *testing.T) {
+	g, gameServerSession := newLocalTestManager(t, 1)
+
+	effective := func() (PlayerSessionCreationPolicy, string) {
+		g.policy.mu.Lock()
+		defer g.policy.mu.Unlock()
+
+		return g.effectivePolicy(gameServerSession)
+	}
+	check := func(name string, wantPolicy PlayerSessionCreationPolicy, wantReason string) {
+		t.Helper()
+		if policy, reason := effective(); policy != wantPolicy || reason != wantReason {
+			t.Errorf("%s: got %s (%s), want %s (%s)", name, policy, reason, wantPolicy, wantReason)
+		}
+	}
+
+	check("empty session", PolicyAcceptAll, "requested")
+
+	g.playerSessions.accepted(gameServerSession.GameServerSessionId, "psess-a")
+	check("full session", PolicyDenyAll, "full")
+
+	g.policy.mu.Lock()
+	g.policy.draining = true
+	g.policy.mu.Unlock()
+	check("draining", PolicyDenyAll, "draining")
+
+	g.policy.mu.Lock()
+	g.policy.auto = false
+	g.policy.mu.Unlock()
+	check("auto switching off", PolicyAcceptAll, "requested")
+
+	g.policy.mu.Lock()
+	g.policy.requested = PolicyDenyAll
+	g.policy.mu.Unlock()
+	check("DENY_ALL requested", PolicyDenyAll, "requested")
+}
+
+func TestApplyPlayerSessionPolicy(t *testing.T) {
+	g, gameServerSession := newLocalTestManager(t, 1)
+
+	g.playerSessions.accepted(gameServerSession.GameServerSessionId, "psess-a")
+	g.applyPlayerSessionPolicy()
+	if got := g.PlayerSessionCreationPolicy(); got != PolicyDenyAll {
+		t.Errorf("policy when full = %s, want DENY_ALL", got)
+	}
+
+	g.playerSessions.removed("psess-a")
+	g.applyPlayerSessionPolicy()
+	if got := g.PlayerSessionCreationPolicy(); got != PolicyAcceptAll {
+		t.Errorf("policy after the player left = %s, want ACCEPT_ALL", got)
+	}
+}
+
+// slowPolicySdk blocks SetPolicy until release is closed.
+type slowPolicySdk struct {
+	*LocalSdk
+	started chan PlayerSessionCreationPolicy
+	release chan struct{}
+}
+
+func (s *slowPolicySdk) SetPolicy(ctx context.Context, gameServerSessionId string, policy PlayerSessionCreationPolicy) error {
+	s.started <- policy
+	<-s.release
+	return s.LocalSdk.SetPolicy(ctx, gameServerSessionId, policy)
+}
+
+func TestSlowSetPolicyDoesNotBlockStatus(t *testing.T) {
+	sdk := &slowPolicySdk{LocalSdk: NewLocalSdk("1"),
+		started: make(chan PlayerSessionCreationPolicy, 2), release: make(chan struct{})}
+	g := newTestManager(t, func(pid string) Sdk { return sdk })
+	gameServerSession := &GameServerSession{GameServerSessionId: "gss-1", MaxPlayers: 1}
+	g.SetGameServerSession(gameServerSession)
+
+	g.playerSessions.accepted(gameServerSession.GameServerSessionId, "psess-a")
+	done := make(chan struct{})
+	go func() {
+		g.applyPlayerSessionPolicy()
+		close(done)
+	}()
+	if got := <-sdk.started; got != PolicyDenyAll {
+		t.Fatalf("first SetPolicy = %s, want DENY_ALL", got)
+	}
+
+	// 发送期间 Status 不等 agent，新的变化交给正在发的一方
+	g.Status()
+	g.playerSessions.removed("psess-a")
+	g.applyPlayerSessionPolicy()
+
+	close(sdk.release)
+	if got := <-sdk.started; got != PolicyAcceptAll {
+		t.Errorf("second SetPolicy = %s, want ACCEPT_ALL", got)
+	}
+	<-done
+	if got := g.PlayerSessionCreationPolicy(); got != PolicyAcceptAll {
+		t.Errorf("policy after the player left = %s, want ACCEPT_ALL", got)
+	}
+}
@@ -0,0 +1,120 @@
+package gsemanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// 可选的游戏服务器后端
+const (
+	BackendGse    = "gse"    // GSE agent，127.0.0.1:10001
+	BackendAgones = "agones" // Agones SDK sidecar
+)
+
+func ParseBackend(value string) (string, error) {
+	switch value {
+	case BackendGse, BackendAgones:
+		return value, nil
+	}
+	return "", fmt.Errorf("unknown backend %q, expect %s or %s", value, BackendGse, BackendAgones)
+}
+
+// PlayerSession is a player session as the platform reports it. The JSON
+// names are those of the agent's PlayerSession message.
+type PlayerSession struct {
+	PlayerSessionId     string              `json:"playerSessionId,omitempty"`
+	PlayerId            string              `json:"playerId,omitempty"`
+	GameServerSessionId string              `json:"gameServerSessionId,omitempty"`
+	FleetId             string              `json:"fleetId,omitempty"`
+	IpAddress           string              `json:"ipAddress,omitempty"`
+	Status              PlayerSessionStatus `json:"status,omitempty"`
+	CreationTime        int64               `json:"creationTime,omitempty"`
+	TerminationTime     int64               `json:"terminationTime,omitempty"`
+	Port                int32               `json:"port,omitempty"`
+	PlayerData          string              `json:"playerData,omitempty"`
+	DnsName             string              `json:"dnsName,omitempty"`
+}
+
+// GameServerSession is the session the process hosts, as the platform
+// delivered it. The JSON names are those of the agent's GameServerSession
+// message.
+type GameServerSession struct {
+	GameServerSessionId   string          `json:"gameServerSessionId,omitempty"`
+	FleetId               string          `json:"fleetId,omitempty"`
+	Name                  string          `json:"name,omitempty"`
+	MaxPlayers            int32           `json:"maxPlayers,omitempty"`
+	Joinable              bool            `json:"joinable,omitempty"`
+	GameProperties        []*GameProperty `json:"gameProperties,omitempty"`
+	Port                  int32           `json:"port,omitempty"`
+	IpAddress             string          `json:"ipAddress,omitempty"`
+	GameServerSessionData string          `json:"gameServerSessionData,omitempty"`
+	MatchmakerData        string          `json:"matchmakerData,omitempty"`
+	DnsName               string          `json:"dnsName,omitempty"`
+}
+
+// GameProperty is one of the properties the session was created with.
+type GameProperty struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// PlayerSessionPage is one page of DescribePlayerSessions.
+type PlayerSessionPage struct {
+	NextToken      string           `json:"nextToken,omitempty"`
+	PlayerSessions []*PlayerSession `json:"playerSessions,omitempty"`
+}
+
+// Sdk is the platform API behind GseManager. GseManager keeps the session,
+// player cache, policy and capacity logic and only calls out through Sdk, so
+// the HTTP and lifecycle layers don't depend on which platform is behind it.
+// Errors are gRPC status errors.
+type Sdk interface {
+	// Ready announces the process, see ProcessReady.
+	Ready(ctx context.Context, logPaths []string, clientPort, grpcPort int32) error
+	ActivateSession(ctx context.Context, gameServerSessionId string, maxPlayers int32) error
+	AcceptPlayer(ctx context.Context, gameServerSessionId, playerSessionId string) error
+	RemovePlayer(ctx context.Context, gameServerSessionId, playerSessionId string) error
+	TerminateSession(ctx context.Context, gameServerSessionId string) error
+	// End tells the platform the process is ending, see ProcessEnding.
+	End(ctx context.Context) error
+	ReportCustomData(ctx context.Context, currentCustomCount, maxCustomCount int32) error
+	// DescribePlayers returns one page of query starting at nextToken, and
+	// the token of the next page or "" after the last one.
+	DescribePlayers(ctx context.Context, query DescribePlayerSessionsQuery, nextToken string) ([]*PlayerSession, string, error)
+	SetPolicy(ctx context.Context, gameServerSessionId string, policy PlayerSessionCreationPolicy) error
+	// PlayerConnected and PlayerDisconnected report players seen in the game
+	// log, for platforms that track players rather than player sessions.
+	PlayerConnected(ctx context.Context, playerId string) error
+	PlayerDisconnected(ctx context.Context, playerId string) error
+}
+
+var (
+	sdkMu sync.RWMutex
+	// newSdk 创建每个进程的 Sdk，默认连接 GSE agent
+	newSdk = func(pid string) Sdk { return newGseSdk(pid) }
+)
+
+// SetSdkFactory makes managers created afterwards use the Sdk factory
+// returns for their pid, e.g. NewLocalSdk for runs without an agent. Call it
+// before the first NewGseManager.
+func SetSdkFactory(factory func(pid string) Sdk) {
+	sdkMu.Lock()
+	defer sdkMu.Unlock()
+
+	newSdk = factory
+}
+
+// managerBinder is implemented by an Sdk whose platform starts sessions and
+// terminations by itself, e.g. through a watch, instead of calling the
+// wrapper's grpc service; newGseManager hands it its manager.
+type managerBinder interface {
+	bind(g *GseManager)
+}
+
+func sdkFor(pid string) Sdk {
+	sdkMu.RLock()
+	defer sdkMu.RUnlock()
+
+	return newSdk(pid)
+}
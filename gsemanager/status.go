@@ -0,0 +1,155 @@
+package gsemanager
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"supertuxkart/logger"
+	"time"
+)
+
+// LifecycleState 进程生命周期，只会前进
+type LifecycleState string
+
+const (
+	StateStarting      LifecycleState = "STARTING"       // 等待 ProcessReady
+	StateProcessReady  LifecycleState = "PROCESS_READY"  // agent 已知道进程
+	StateServerReady   LifecycleState = "SERVER_READY"   // STK 开始监听
+	StateSessionActive LifecycleState = "SESSION_ACTIVE" // 游戏会话已激活
+	StateTerminating   LifecycleState = "TERMINATING"    // 收到 OnProcessTerminate
+	StateEnded         LifecycleState = "ENDED"          // ProcessEnding 已发送
+)
+
+var stateOrder = map[LifecycleState]int{
+	StateStarting:      0,
+	StateProcessReady:  1,
+	StateServerReady:   2,
+	StateSessionActive: 3,
+	StateTerminating:   4,
+	StateEnded:         5,
+}
+
+// AtLeast reports whether s is state or a later one.
+func (s LifecycleState) AtLeast(state LifecycleState) bool {
+	return stateOrder[s] >= stateOrder[state]
+}
+
+// startTime 近似为 wrapper 启动时间
+var startTime = time.Now()
+
+// Status is a snapshot of the wrapper's view of the game server process.
+type Status struct {
+	State               LifecycleState
+	Pid                 int
+	GameServerSessionId string
+	TerminationTime     int64
+	ClientPort          int
+	GrpcPort            int
+	HttpPort            int
+	StartTime           time.Time
+	Policy              PlayerSessionCreationPolicy
+	Draining            bool
+	MaxPlayers          int
+	PlayerSessions      int
+	Termination         Termination
+}
+
+// AdvanceState moves the lifecycle to state unless it is already past it.
+func (g *GseManager) AdvanceState(state LifecycleState) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state.AtLeast(state) {
+		return
+	}
+	logger.Info("lifecycle state changed", zap.String("from", string(g.state)), zap.String("to", string(state)))
+	g.state = state
+	close(g.stateChanged)
+	g.stateChanged = make(chan struct{})
+}
+
+// WaitForState blocks until the lifecycle has reached state or ctx is done,
+// then returns ctx's error as a gRPC status.
+func (g *GseManager) WaitForState(ctx context.Context, state LifecycleState) error {
+	for {
+		g.mu.RLock()
+		reached := g.state.AtLeast(state)
+		changed := g.stateChanged
+		g.mu.RUnlock()
+
+		if reached {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// endSession records that TerminateGameServerSession ended the session
+// gameServerSessionId, unless another one has replaced it since. The
+// lifecycle stays SESSION_ACTIVE; the process may host a new session.
+func (g *GseManager) endSession(gameServerSessionId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.gameServerSession != nil && g.gameServerSession.GameServerSessionId == gameServerSessionId {
+		g.sessionEnded = true
+	}
+}
+
+// SessionLive reports whether the process hosts an activated session that
+// TerminateGameServerSession has not ended.
+func (g *GseManager) SessionLive() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.state == StateSessionActive && g.gameServerSession != nil && !g.sessionEnded
+}
+
+func (g *GseManager) State() LifecycleState {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.state
+}
+
+// SetHttpPort records the local http api port for Status.
+func (g *GseManager) SetHttpPort(httpPort int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.httpPort = httpPort
+}
+
+func (g *GseManager) Status() Status {
+	g.mu.RLock()
+	pid, _ := strconv.Atoi(g.pid)
+	st := Status{
+		State:           g.state,
+		Pid:             pid,
+		TerminationTime: g.terminationTime,
+		ClientPort:      g.clientPort,
+		GrpcPort:        g.grpcPort,
+		HttpPort:        g.httpPort,
+		StartTime:       startTime,
+	}
+	gameServerSession := g.gameServerSession
+	g.mu.RUnlock()
+
+	g.policy.mu.Lock()
+	st.Policy = g.policy.current
+	st.Draining = g.policy.draining
+	g.policy.mu.Unlock()
+
+	st.Termination = g.Termination()
+	st.MaxPlayers = g.maxPlayersOf(gameServerSession)
+	if gameServerSession != nil {
+		st.GameServerSessionId = gameServerSession.GameServerSessionId
+		st.PlayerSessions = g.playerSessions.occupied(gameServerSession.GameServerSessionId)
+	}
+	return st
+}
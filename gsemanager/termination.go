@@ -0,0 +1,186 @@
+package gsemanager
+
+import (
+	"go.uber.org/zap"
+	"supertuxkart/logger"
+	"sync"
+	"time"
+)
+
+// TerminationPhase 进程终止流程的阶段
+type TerminationPhase string
+
+const (
+	TerminationNone          TerminationPhase = ""
+	TerminationDraining      TerminationPhase = "DRAINING"            // 拒绝新玩家，等待在线玩家离开
+	TerminationEndingSession TerminationPhase = "TERMINATING_SESSION" // TerminateGameServerSession
+	TerminationEndingProcess TerminationPhase = "ENDING_PROCESS"      // ProcessEnding
+	TerminationDone          TerminationPhase = "DONE"
+	TerminationFailed        TerminationPhase = "FAILED" // 截止时间前没有完成
+)
+
+// Termination is the progress of the shutdown OnProcessTerminate started.
+type Termination struct {
+	Phase     TerminationPhase
+	Deadline  time.Time
+	LastError string
+}
+
+// TerminationOptions tune the shutdown workflow.
+type TerminationOptions struct {
+	// Grace is the time allowed when the agent gives no termination time,
+	// or one that has already passed.
+	Grace time.Duration
+	// EndReserve is kept before the deadline for TerminateGameServerSession
+	// and ProcessEnding; draining stops waiting for players then.
+	EndReserve time.Duration
+	// Done, if set, is called with the final progress once the workflow has
+	// finished, DONE or FAILED.
+	Done func(Termination)
+}
+
+type termination struct {
+	mu       sync.Mutex
+	progress Termination
+}
+
+// Termination returns the progress of the shutdown workflow.
+func (g *GseManager) Termination() Termination {
+	g.termination.mu.Lock()
+	defer g.termination.mu.Unlock()
+
+	return g.termination.progress
+}
+
+func (g *GseManager) setTermination(phase TerminationPhase, err error) {
+	g.termination.mu.Lock()
+	defer g.termination.mu.Unlock()
+
+	if phase != "" {
+		logger.Info("termination phase", zap.String("from", string(g.termination.progress.Phase)),
+			zap.String("to", string(phase)))
+		g.termination.progress.Phase = phase
+	}
+	if err != nil {
+		g.termination.progress.LastError = err.Error()
+	}
+}
+
+// BeginTermination records terminationTime and starts the shutdown workflow
+// in the background: drain, TerminateGameServerSession, ProcessEnding, each
+// retried until the deadline terminationTime gives. It returns false, doing
+// nothing, when the workflow has already been started.
+func (g *GseManager) BeginTermination(terminationTime int64, opts TerminationOptions) bool {
+	deadline := terminationDeadline(terminationTime, opts.Grace)
+
+	g.termination.mu.Lock()
+	if g.termination.progress.Phase != TerminationNone {
+		g.termination.mu.Unlock()
+		return false
+	}
+	g.termination.progress = Termination{Phase: TerminationDraining, Deadline: deadline}
+	g.termination.mu.Unlock()
+
+	logger.Info("termination started", zap.Int64("terminationTime", terminationTime), zap.Time("deadline", deadline))
+	g.SetTerminationTime(terminationTime)
+	go g.runTermination(deadline, opts)
+	return true
+}
+
+// terminationDeadline 兼容 unix 秒和毫秒；只有没给时间或时间已经过去时才用 grace
+func terminationDeadline(terminationTime int64, grace time.Duration) time.Time {
+	var deadline time.Time
+	switch {
+	case terminationTime > 1e12:
+		deadline = time.Unix(0, terminationTime*int64(time.Millisecond))
+	case terminationTime > 0:
+		deadline = time.Unix(terminationTime, 0)
+	}
+
+	if now := time.Now(); !deadline.After(now) {
+		deadline = now.Add(grace)
+	}
+	return deadline
+}
+
+func (g *GseManager) runTermination(deadline time.Time, opts TerminationOptions) {
+	defer g.trace.End()
+	if opts.Done != nil {
+		defer func() { opts.Done(g.Termination()) }()
+	}
+
+	// 进入 drain，不再接受新玩家
+	g.SetDraining(true)
+	g.waitForPlayersToLeave(deadline.Add(-opts.EndReserve))
+
+	//结束游戏会话
+	g.setTermination(TerminationEndingSession, nil)
+	err := retryUntil(deadline, func() error {
+		err := g.TerminateGameServerSession()
+		if err == errNoGameServerSession {
+			return nil
+		}
+		if err != nil {
+			g.setTermination("", err)
+		}
+		return err
+	})
+	if err != nil {
+		logger.Warn("TerminateGameServerSession fail, ending the process anyway", zap.Error(err))
+	}
+
+	// 进程退出
+	g.setTermination(TerminationEndingProcess, nil)
+	err = retryUntil(deadline, func() error {
+		if g.State() == StateEnded {
+			return nil
+		}
+		err := g.ProcessEnding()
+		if err != nil {
+			g.setTermination("", err)
+		}
+		return err
+	})
+	if err != nil {
+		logger.Error("ProcessEnding fail before the termination deadline", zap.Error(err))
+		g.setTermination(TerminationFailed, err)
+		return
+	}
+	g.setTermination(TerminationDone, nil)
+}
+
+func (g *GseManager) waitForPlayersToLeave(until time.Time) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		online := g.playerSessions.online()
+		if online == 0 {
+			return
+		}
+		if !time.Now().Before(until) {
+			logger.Info("players still online at the drain deadline", zap.Int("online", online))
+			return
+		}
+		<-ticker.C
+	}
+}
+
+// retryUntil calls fn with a growing pause until it succeeds or deadline
+// passes, and returns the last error.
+func retryUntil(deadline time.Time, fn func() error) error {
+	pause := 500 * time.Millisecond
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(pause).After(deadline) {
+			return err
+		}
+		time.Sleep(pause)
+		if pause < 5*time.Second {
+			pause *= 2
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package gsemanager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTerminationDeadline(t *testing.T) {
+	const grace = 30 * time.Second
+	future := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+
+	tests := []struct {
+		name            string
+		terminationTime int64
+		want            time.Time
+	}{
+		{name: "unix seconds", terminationTime: future.Unix(), want: time.Unix(future.Unix(), 0)},
+		{name: "unix milliseconds", terminationTime: future.UnixNano() / int64(time.Millisecond), want: future},
+	}
+	for _, tt := range tests {
+		if got := terminationDeadline(tt.terminationTime, grace); !got.Equal(tt.want) {
+			t.Errorf("%s: deadline = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// 没给时间或时间已经过去，才用 grace
+	past := time.Now().Add(-time.Minute)
+	for name, terminationTime := range map[string]int64{
+		"missing":                0,
+		"past unix seconds":      past.Unix(),
+		"past unix milliseconds": past.UnixNano() / int64(time.Millisecond),
+	} {
+		before := time.Now()
+		got := terminationDeadline(terminationTime, grace)
+		if got.Before(before.Add(grace)) || got.After(time.Now().Add(grace)) {
+			t.Errorf("%s: deadline = %v, want now + %v", name, got, grace)
+		}
+	}
+}
+
+func TestBeginTerminationOnce(t *testing.T) {
+	g, _ := newLocalTestManager(t, 0)
+
+	done := make(chan Termination, 2)
+	opts := TerminationOptions{Grace: time.Second, Done: func(tm Termination) { done <- tm }}
+	if !g.BeginTermination(0, opts) {
+		t.Fatal("first BeginTermination returned false")
+	}
+	if g.BeginTermination(0, opts) {
+		t.Error("second BeginTermination returned true")
+	}
+
+	select {
+	case tm := <-done:
+		if tm.Phase != TerminationDone {
+			t.Errorf("final phase = %s, want DONE", tm.Phase)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("termination did not finish")
+	}
+	if g.State() != StateEnded {
+		t.Errorf("state = %s, want ENDED", g.State())
+	}
+	select {
+	case <-done:
+		t.Error("Done called twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hpcloud/tail"
+)
+
+// 启动结果
+const (
+	launchReady    = "READY"    // 日志出现 Listening has been started
+	launchBindFail = "BINDFAIL" // 端口绑定失败，可以换端口重试
+	launchTimeout  = "TIMEOUT"  // 超时，按旧的行为继续
+)
+
+// game is one launched SuperTuxKart process and the tail of its log.
+type game struct {
+	cmd    *exec.Cmd
+	port   int
+	tail   *tail.Tail
+	exited chan error
+}
+
+// launchGame starts the server binary on port and tails its log. The old log
+// is removed first so that lines of an earlier attempt are not read again.
+func launchGame(input string, port int, extraArgs, env []string, logPath string) (*game, error) {
+	cmdString := strings.Split(input, " ")
+	cmdString = append(cmdString, "--port="+strconv.Itoa(port))
+	cmdString = append(cmdString, extraArgs...)
+	command, args := cmdString[0], cmdString[1:]
+
+	log.Printf("Command being run for SuperTuxKart server: %s \n", cmdString)
+
+	if err := os.Remove(logPath); err != nil && !os.IsNotExist(err) {
+		log.Printf("could not remove old log %s: %v", logPath, err)
+	}
+	// tail 需要目录已经存在才能等待日志文件出现
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(command, args...) // #nosec
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = os.Stdout
+	cmd.Env = env
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	// SuperTuxKart refuses to output to foreground, so we're going to
+	// poll the server log. The file may not exist yet; tail waits for it.
+	t, err := tail.TailFile(logPath, tail.Config{Follow: true, ReOpen: true})
+	if err != nil {
+		cmd.Process.Kill()
+		return nil, err
+	}
+
+	g := &game{
+		cmd:    cmd,
+		port:   port,
+		tail:   t,
+		exited: make(chan error, 1),
+	}
+	go func() {
+		g.exited <- cmd.Wait()
+	}()
+	return g, nil
+}
+
+// waitForListening echoes the log until the server listens, fails to bind
+// its port or timeout passes. It returns the result and, for launchReady,
+// the matching line.
+func (g *game) waitForListening(timeout time.Duration) (string, string, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// 进程退出后再读一会日志，绑定失败的那一行可能还没读到
+	var exitErr error
+	var grace <-chan time.Time
+	exited := g.exited
+
+	for {
+		select {
+		case line, ok := <-g.tail.Lines:
+			if !ok {
+				return "", "", fmt.Errorf("log tail ended")
+			}
+			fmt.Println(line.Text)
+			switch action, _ := handleLogLine(line.Text); action {
+			case launchReady:
+				return launchReady, line.Text, nil
+			case launchBindFail:
+				return launchBindFail, line.Text, nil
+			}
+		case exitErr = <-exited:
+			g.exited <- exitErr
+			exited = nil
+			grace = time.After(time.Second)
+		case <-grace:
+			return "", "", fmt.Errorf("server exited before listening: %v", exitErr)
+		case <-timer.C:
+			return launchTimeout, "", nil
+		}
+	}
+}
+
+// stop kills the process and stops tailing its log.
+func (g *game) stop() {
+	g.cmd.Process.Kill()
+	<-g.exited
+	g.tail.Stop()
+	g.tail.Cleanup()
+}
@@ -0,0 +1,2640 @@
+// 生成代码:
+//   protoc -I . -I <googleapis> --go_out=plugins=grpc:. --grpc-gateway_out=. local_grpc_service.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: local_grpc_service.proto
+
+package localsdk
+
+import (
+	context "context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PlayerSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerSessionId string `protobuf:"bytes,1,opt,name=playerSessionId,proto3" json:"playerSessionId,omitempty"`
+}
+
+func (x *PlayerSessionRequest) Reset() {
+	*x = PlayerSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerSessionRequest) ProtoMessage() {}
+
+func (x *PlayerSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerSessionRequest.ProtoReflect.Descriptor instead.
+func (*PlayerSessionRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlayerSessionRequest) GetPlayerSessionId() string {
+	if x != nil {
+		return x.PlayerSessionId
+	}
+	return ""
+}
+
+type BatchPlayerSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerSessionIds []string `protobuf:"bytes,1,rep,name=playerSessionIds,proto3" json:"playerSessionIds,omitempty"`
+}
+
+func (x *BatchPlayerSessionRequest) Reset() {
+	*x = BatchPlayerSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchPlayerSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPlayerSessionRequest) ProtoMessage() {}
+
+func (x *BatchPlayerSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPlayerSessionRequest.ProtoReflect.Descriptor instead.
+func (*BatchPlayerSessionRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchPlayerSessionRequest) GetPlayerSessionIds() []string {
+	if x != nil {
+		return x.PlayerSessionIds
+	}
+	return nil
+}
+
+type PlayerSessionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerSessionId string `protobuf:"bytes,1,opt,name=playerSessionId,proto3" json:"playerSessionId,omitempty"`
+	// gRPC code name, "OK" on success
+	Code    string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *PlayerSessionResult) Reset() {
+	*x = PlayerSessionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerSessionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerSessionResult) ProtoMessage() {}
+
+func (x *PlayerSessionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerSessionResult.ProtoReflect.Descriptor instead.
+func (*PlayerSessionResult) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlayerSessionResult) GetPlayerSessionId() string {
+	if x != nil {
+		return x.PlayerSessionId
+	}
+	return ""
+}
+
+func (x *PlayerSessionResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PlayerSessionResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BatchPlayerSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*PlayerSessionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchPlayerSessionResponse) Reset() {
+	*x = BatchPlayerSessionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchPlayerSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPlayerSessionResponse) ProtoMessage() {}
+
+func (x *BatchPlayerSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPlayerSessionResponse.ProtoReflect.Descriptor instead.
+func (*BatchPlayerSessionResponse) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchPlayerSessionResponse) GetResults() []*PlayerSessionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type DescribePlayerSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameServerSessionId       string `protobuf:"bytes,1,opt,name=gameServerSessionId,proto3" json:"gameServerSessionId,omitempty"`
+	PlayerId                  string `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	PlayerSessionId           string `protobuf:"bytes,3,opt,name=playerSessionId,proto3" json:"playerSessionId,omitempty"`
+	PlayerSessionStatusFilter string `protobuf:"bytes,4,opt,name=playerSessionStatusFilter,proto3" json:"playerSessionStatusFilter,omitempty"`
+	NextToken                 string `protobuf:"bytes,5,opt,name=nextToken,proto3" json:"nextToken,omitempty"`
+	Limit                     int32  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *DescribePlayerSessionsRequest) Reset() {
+	*x = DescribePlayerSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DescribePlayerSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribePlayerSessionsRequest) ProtoMessage() {}
+
+func (x *DescribePlayerSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribePlayerSessionsRequest.ProtoReflect.Descriptor instead.
+func (*DescribePlayerSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DescribePlayerSessionsRequest) GetGameServerSessionId() string {
+	if x != nil {
+		return x.GameServerSessionId
+	}
+	return ""
+}
+
+func (x *DescribePlayerSessionsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *DescribePlayerSessionsRequest) GetPlayerSessionId() string {
+	if x != nil {
+		return x.PlayerSessionId
+	}
+	return ""
+}
+
+func (x *DescribePlayerSessionsRequest) GetPlayerSessionStatusFilter() string {
+	if x != nil {
+		return x.PlayerSessionStatusFilter
+	}
+	return ""
+}
+
+func (x *DescribePlayerSessionsRequest) GetNextToken() string {
+	if x != nil {
+		return x.NextToken
+	}
+	return ""
+}
+
+func (x *DescribePlayerSessionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type PlayerSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerSessionId     string `protobuf:"bytes,1,opt,name=playerSessionId,proto3" json:"playerSessionId,omitempty"`
+	PlayerId            string `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	GameServerSessionId string `protobuf:"bytes,3,opt,name=gameServerSessionId,proto3" json:"gameServerSessionId,omitempty"`
+	FleetId             string `protobuf:"bytes,4,opt,name=fleetId,proto3" json:"fleetId,omitempty"`
+	IpAddress           string `protobuf:"bytes,5,opt,name=ipAddress,proto3" json:"ipAddress,omitempty"`
+	Status              string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreationTime        int64  `protobuf:"varint,7,opt,name=creationTime,proto3" json:"creationTime,omitempty"`
+	TerminationTime     int64  `protobuf:"varint,8,opt,name=terminationTime,proto3" json:"terminationTime,omitempty"`
+	Port                int32  `protobuf:"varint,9,opt,name=port,proto3" json:"port,omitempty"`
+	PlayerData          string `protobuf:"bytes,10,opt,name=playerData,proto3" json:"playerData,omitempty"`
+	DnsName             string `protobuf:"bytes,11,opt,name=dnsName,proto3" json:"dnsName,omitempty"`
+}
+
+func (x *PlayerSession) Reset() {
+	*x = PlayerSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerSession) ProtoMessage() {}
+
+func (x *PlayerSession) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerSession.ProtoReflect.Descriptor instead.
+func (*PlayerSession) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PlayerSession) GetPlayerSessionId() string {
+	if x != nil {
+		return x.PlayerSessionId
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetGameServerSessionId() string {
+	if x != nil {
+		return x.GameServerSessionId
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetFleetId() string {
+	if x != nil {
+		return x.FleetId
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetCreationTime() int64 {
+	if x != nil {
+		return x.CreationTime
+	}
+	return 0
+}
+
+func (x *PlayerSession) GetTerminationTime() int64 {
+	if x != nil {
+		return x.TerminationTime
+	}
+	return 0
+}
+
+func (x *PlayerSession) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PlayerSession) GetPlayerData() string {
+	if x != nil {
+		return x.PlayerData
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetDnsName() string {
+	if x != nil {
+		return x.DnsName
+	}
+	return ""
+}
+
+type DescribePlayerSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NextToken      string           `protobuf:"bytes,1,opt,name=nextToken,proto3" json:"nextToken,omitempty"`
+	PlayerSessions []*PlayerSession `protobuf:"bytes,2,rep,name=playerSessions,proto3" json:"playerSessions,omitempty"`
+}
+
+func (x *DescribePlayerSessionsResponse) Reset() {
+	*x = DescribePlayerSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DescribePlayerSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribePlayerSessionsResponse) ProtoMessage() {}
+
+func (x *DescribePlayerSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribePlayerSessionsResponse.ProtoReflect.Descriptor instead.
+func (*DescribePlayerSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DescribePlayerSessionsResponse) GetNextToken() string {
+	if x != nil {
+		return x.NextToken
+	}
+	return ""
+}
+
+func (x *DescribePlayerSessionsResponse) GetPlayerSessions() []*PlayerSession {
+	if x != nil {
+		return x.PlayerSessions
+	}
+	return nil
+}
+
+type QueryCachedPlayerSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerSessionId string `protobuf:"bytes,1,opt,name=playerSessionId,proto3" json:"playerSessionId,omitempty"`
+	PlayerId        string `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Status          string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *QueryCachedPlayerSessionsRequest) Reset() {
+	*x = QueryCachedPlayerSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryCachedPlayerSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCachedPlayerSessionsRequest) ProtoMessage() {}
+
+func (x *QueryCachedPlayerSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCachedPlayerSessionsRequest.ProtoReflect.Descriptor instead.
+func (*QueryCachedPlayerSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QueryCachedPlayerSessionsRequest) GetPlayerSessionId() string {
+	if x != nil {
+		return x.PlayerSessionId
+	}
+	return ""
+}
+
+func (x *QueryCachedPlayerSessionsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *QueryCachedPlayerSessionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CachedPlayerSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerSessionId     string `protobuf:"bytes,1,opt,name=playerSessionId,proto3" json:"playerSessionId,omitempty"`
+	PlayerId            string `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	GameServerSessionId string `protobuf:"bytes,3,opt,name=gameServerSessionId,proto3" json:"gameServerSessionId,omitempty"`
+	Status              string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// 游戏日志中已出现该玩家
+	Connected bool `protobuf:"varint,5,opt,name=connected,proto3" json:"connected,omitempty"`
+	// unix 毫秒
+	UpdateTime int64 `protobuf:"varint,6,opt,name=updateTime,proto3" json:"updateTime,omitempty"`
+}
+
+func (x *CachedPlayerSession) Reset() {
+	*x = CachedPlayerSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CachedPlayerSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedPlayerSession) ProtoMessage() {}
+
+func (x *CachedPlayerSession) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedPlayerSession.ProtoReflect.Descriptor instead.
+func (*CachedPlayerSession) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CachedPlayerSession) GetPlayerSessionId() string {
+	if x != nil {
+		return x.PlayerSessionId
+	}
+	return ""
+}
+
+func (x *CachedPlayerSession) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *CachedPlayerSession) GetGameServerSessionId() string {
+	if x != nil {
+		return x.GameServerSessionId
+	}
+	return ""
+}
+
+func (x *CachedPlayerSession) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CachedPlayerSession) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *CachedPlayerSession) GetUpdateTime() int64 {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return 0
+}
+
+type QueryCachedPlayerSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerSessions []*CachedPlayerSession `protobuf:"bytes,1,rep,name=playerSessions,proto3" json:"playerSessions,omitempty"`
+}
+
+func (x *QueryCachedPlayerSessionsResponse) Reset() {
+	*x = QueryCachedPlayerSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryCachedPlayerSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCachedPlayerSessionsResponse) ProtoMessage() {}
+
+func (x *QueryCachedPlayerSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCachedPlayerSessionsResponse.ProtoReflect.Descriptor instead.
+func (*QueryCachedPlayerSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QueryCachedPlayerSessionsResponse) GetPlayerSessions() []*CachedPlayerSession {
+	if x != nil {
+		return x.PlayerSessions
+	}
+	return nil
+}
+
+type GetGameServerSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetGameServerSessionRequest) Reset() {
+	*x = GetGameServerSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGameServerSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameServerSessionRequest) ProtoMessage() {}
+
+func (x *GetGameServerSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameServerSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetGameServerSessionRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{10}
+}
+
+type GameProperty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GameProperty) Reset() {
+	*x = GameProperty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameProperty) ProtoMessage() {}
+
+func (x *GameProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameProperty.ProtoReflect.Descriptor instead.
+func (*GameProperty) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GameProperty) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GameProperty) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type GameServerSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameServerSessionId   string          `protobuf:"bytes,1,opt,name=gameServerSessionId,proto3" json:"gameServerSessionId,omitempty"`
+	FleetId               string          `protobuf:"bytes,2,opt,name=fleetId,proto3" json:"fleetId,omitempty"`
+	Name                  string          `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	MaxPlayers            int32           `protobuf:"varint,4,opt,name=maxPlayers,proto3" json:"maxPlayers,omitempty"`
+	Joinable              bool            `protobuf:"varint,5,opt,name=joinable,proto3" json:"joinable,omitempty"`
+	GameProperties        []*GameProperty `protobuf:"bytes,6,rep,name=gameProperties,proto3" json:"gameProperties,omitempty"`
+	Port                  int32           `protobuf:"varint,7,opt,name=port,proto3" json:"port,omitempty"`
+	IpAddress             string          `protobuf:"bytes,8,opt,name=ipAddress,proto3" json:"ipAddress,omitempty"`
+	GameServerSessionData string          `protobuf:"bytes,9,opt,name=gameServerSessionData,proto3" json:"gameServerSessionData,omitempty"`
+	MatchmakerData        string          `protobuf:"bytes,10,opt,name=matchmakerData,proto3" json:"matchmakerData,omitempty"`
+	DnsName               string          `protobuf:"bytes,11,opt,name=dnsName,proto3" json:"dnsName,omitempty"`
+}
+
+func (x *GameServerSession) Reset() {
+	*x = GameServerSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameServerSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameServerSession) ProtoMessage() {}
+
+func (x *GameServerSession) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameServerSession.ProtoReflect.Descriptor instead.
+func (*GameServerSession) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GameServerSession) GetGameServerSessionId() string {
+	if x != nil {
+		return x.GameServerSessionId
+	}
+	return ""
+}
+
+func (x *GameServerSession) GetFleetId() string {
+	if x != nil {
+		return x.FleetId
+	}
+	return ""
+}
+
+func (x *GameServerSession) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GameServerSession) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *GameServerSession) GetJoinable() bool {
+	if x != nil {
+		return x.Joinable
+	}
+	return false
+}
+
+func (x *GameServerSession) GetGameProperties() []*GameProperty {
+	if x != nil {
+		return x.GameProperties
+	}
+	return nil
+}
+
+func (x *GameServerSession) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *GameServerSession) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *GameServerSession) GetGameServerSessionData() string {
+	if x != nil {
+		return x.GameServerSessionData
+	}
+	return ""
+}
+
+func (x *GameServerSession) GetMatchmakerData() string {
+	if x != nil {
+		return x.MatchmakerData
+	}
+	return ""
+}
+
+func (x *GameServerSession) GetDnsName() string {
+	if x != nil {
+		return x.DnsName
+	}
+	return ""
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{13}
+}
+
+type WrapperStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// STARTING, PROCESS_READY, SERVER_READY, SESSION_ACTIVE, TERMINATING or ENDED
+	State string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// 游戏进程 pid
+	Pid                 int32  `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	GameServerSessionId string `protobuf:"bytes,3,opt,name=gameServerSessionId,proto3" json:"gameServerSessionId,omitempty"`
+	// OnProcessTerminate 给出的时间，0 表示未收到
+	TerminationTime int64 `protobuf:"varint,4,opt,name=terminationTime,proto3" json:"terminationTime,omitempty"`
+	ClientPort      int32 `protobuf:"varint,5,opt,name=clientPort,proto3" json:"clientPort,omitempty"`
+	GrpcPort        int32 `protobuf:"varint,6,opt,name=grpcPort,proto3" json:"grpcPort,omitempty"`
+	HttpPort        int32 `protobuf:"varint,7,opt,name=httpPort,proto3" json:"httpPort,omitempty"`
+	// unix 毫秒
+	StartTime                   int64  `protobuf:"varint,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	UptimeSeconds               int64  `protobuf:"varint,9,opt,name=uptimeSeconds,proto3" json:"uptimeSeconds,omitempty"`
+	Healthy                     bool   `protobuf:"varint,10,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	PlayerSessionCreationPolicy string `protobuf:"bytes,11,opt,name=playerSessionCreationPolicy,proto3" json:"playerSessionCreationPolicy,omitempty"`
+	Draining                    bool   `protobuf:"varint,12,opt,name=draining,proto3" json:"draining,omitempty"`
+	// 生效的人数上限，0 表示不限
+	MaxPlayers int32 `protobuf:"varint,13,opt,name=maxPlayers,proto3" json:"maxPlayers,omitempty"`
+	// RESERVED 和 ACTIVE 的玩家会话数
+	PlayerSessions int32 `protobuf:"varint,14,opt,name=playerSessions,proto3" json:"playerSessions,omitempty"`
+	// OnProcessTerminate 之后的进度：DRAINING, TERMINATING_SESSION, ENDING_PROCESS, DONE 或 FAILED
+	TerminationPhase string `protobuf:"bytes,15,opt,name=terminationPhase,proto3" json:"terminationPhase,omitempty"`
+	// 终止流程的截止时间，unix 毫秒
+	TerminationDeadline int64 `protobuf:"varint,16,opt,name=terminationDeadline,proto3" json:"terminationDeadline,omitempty"`
+	// 终止流程最近一次调用 agent 失败的原因
+	TerminationError string `protobuf:"bytes,17,opt,name=terminationError,proto3" json:"terminationError,omitempty"`
+}
+
+func (x *WrapperStatus) Reset() {
+	*x = WrapperStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WrapperStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WrapperStatus) ProtoMessage() {}
+
+func (x *WrapperStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WrapperStatus.ProtoReflect.Descriptor instead.
+func (*WrapperStatus) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WrapperStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *WrapperStatus) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetGameServerSessionId() string {
+	if x != nil {
+		return x.GameServerSessionId
+	}
+	return ""
+}
+
+func (x *WrapperStatus) GetTerminationTime() int64 {
+	if x != nil {
+		return x.TerminationTime
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetClientPort() int32 {
+	if x != nil {
+		return x.ClientPort
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetGrpcPort() int32 {
+	if x != nil {
+		return x.GrpcPort
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetHttpPort() int32 {
+	if x != nil {
+		return x.HttpPort
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *WrapperStatus) GetPlayerSessionCreationPolicy() string {
+	if x != nil {
+		return x.PlayerSessionCreationPolicy
+	}
+	return ""
+}
+
+func (x *WrapperStatus) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *WrapperStatus) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetPlayerSessions() int32 {
+	if x != nil {
+		return x.PlayerSessions
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetTerminationPhase() string {
+	if x != nil {
+		return x.TerminationPhase
+	}
+	return ""
+}
+
+func (x *WrapperStatus) GetTerminationDeadline() int64 {
+	if x != nil {
+		return x.TerminationDeadline
+	}
+	return 0
+}
+
+func (x *WrapperStatus) GetTerminationError() string {
+	if x != nil {
+		return x.TerminationError
+	}
+	return ""
+}
+
+type UpdatePlayerSessionCreationPolicyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NewPlayerSessionCreationPolicy string `protobuf:"bytes,1,opt,name=newPlayerSessionCreationPolicy,proto3" json:"newPlayerSessionCreationPolicy,omitempty"`
+}
+
+func (x *UpdatePlayerSessionCreationPolicyRequest) Reset() {
+	*x = UpdatePlayerSessionCreationPolicyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePlayerSessionCreationPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlayerSessionCreationPolicyRequest) ProtoMessage() {}
+
+func (x *UpdatePlayerSessionCreationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlayerSessionCreationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePlayerSessionCreationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdatePlayerSessionCreationPolicyRequest) GetNewPlayerSessionCreationPolicy() string {
+	if x != nil {
+		return x.NewPlayerSessionCreationPolicy
+	}
+	return ""
+}
+
+type TerminateGameServerSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TerminateGameServerSessionRequest) Reset() {
+	*x = TerminateGameServerSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TerminateGameServerSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateGameServerSessionRequest) ProtoMessage() {}
+
+func (x *TerminateGameServerSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateGameServerSessionRequest.ProtoReflect.Descriptor instead.
+func (*TerminateGameServerSessionRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{16}
+}
+
+type ProcessEndingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProcessEndingRequest) Reset() {
+	*x = ProcessEndingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessEndingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessEndingRequest) ProtoMessage() {}
+
+func (x *ProcessEndingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessEndingRequest.ProtoReflect.Descriptor instead.
+func (*ProcessEndingRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{17}
+}
+
+type ReportCustomDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CurrentCustomCount int32 `protobuf:"varint,1,opt,name=currentCustomCount,proto3" json:"currentCustomCount,omitempty"`
+	MaxCustomCount     int32 `protobuf:"varint,2,opt,name=maxCustomCount,proto3" json:"maxCustomCount,omitempty"`
+}
+
+func (x *ReportCustomDataRequest) Reset() {
+	*x = ReportCustomDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportCustomDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportCustomDataRequest) ProtoMessage() {}
+
+func (x *ReportCustomDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportCustomDataRequest.ProtoReflect.Descriptor instead.
+func (*ReportCustomDataRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReportCustomDataRequest) GetCurrentCustomCount() int32 {
+	if x != nil {
+		return x.CurrentCustomCount
+	}
+	return 0
+}
+
+func (x *ReportCustomDataRequest) GetMaxCustomCount() int32 {
+	if x != nil {
+		return x.MaxCustomCount
+	}
+	return 0
+}
+
+type SetHealthStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HealthStatus bool `protobuf:"varint,1,opt,name=healthStatus,proto3" json:"healthStatus,omitempty"`
+}
+
+func (x *SetHealthStatusRequest) Reset() {
+	*x = SetHealthStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetHealthStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHealthStatusRequest) ProtoMessage() {}
+
+func (x *SetHealthStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHealthStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetHealthStatusRequest) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetHealthStatusRequest) GetHealthStatus() bool {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return false
+}
+
+type LocalResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LocalResponse) Reset() {
+	*x = LocalResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_local_grpc_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalResponse) ProtoMessage() {}
+
+func (x *LocalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_local_grpc_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalResponse.ProtoReflect.Descriptor instead.
+func (*LocalResponse) Descriptor() ([]byte, []int) {
+	return file_local_grpc_service_proto_rawDescGZIP(), []int{20}
+}
+
+var File_local_grpc_service_proto protoreflect.FileDescriptor
+
+var file_local_grpc_service_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x40, 0x0a, 0x14, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28,
+	0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x47, 0x0a, 0x19, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x10, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x73, 0x22, 0x6d, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x59, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x89, 0x02, 0x0a, 0x1d,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a,
+	0x13, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x67, 0x61, 0x6d, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x19, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xf3, 0x02, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x30, 0x0a, 0x13, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x67, 0x61,
+	0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6c, 0x65, 0x65, 0x74, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x66, 0x6c, 0x65, 0x65, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x69,
+	0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6e, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6e, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x83, 0x01,
+	0x0a, 0x1e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x43,
+	0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x20, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xe3, 0x01, 0x0a, 0x13, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28,
+	0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x13, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x13, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x6e, 0x0a, 0x21,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x49, 0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x1d, 0x0a, 0x1b,
+	0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x36, 0x0a, 0x0c, 0x47,
+	0x61, 0x6d, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x9d, 0x03, 0x0a, 0x11, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x13, 0x67, 0x61, 0x6d,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x66,
+	0x6c, 0x65, 0x65, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x6c,
+	0x65, 0x65, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6a, 0x6f, 0x69,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6a, 0x6f, 0x69,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x67, 0x61, 0x6d, 0x65, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x61, 0x6d,
+	0x65, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x0e, 0x67, 0x61, 0x6d, 0x65, 0x50,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x34, 0x0a, 0x15, 0x67,
+	0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x44, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x67, 0x61, 0x6d, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x6d, 0x61, 0x6b, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x6d, 0x61, 0x6b, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6e, 0x73,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6e, 0x73, 0x4e,
+	0x61, 0x6d, 0x65, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xf9, 0x04, 0x0a, 0x0d, 0x57, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69,
+	0x64, 0x12, 0x30, 0x0a, 0x13, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13,
+	0x67, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x65,
+	0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x67, 0x72, 0x70, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x67, 0x72, 0x70, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x74, 0x74,
+	0x70, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x68, 0x74, 0x74,
+	0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x70, 0x74, 0x69,
+	0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x79, 0x12, 0x40, 0x0a, 0x1b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x13, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x65, 0x72, 0x6d, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x72, 0x0a, 0x28, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x46, 0x0a, 0x1e, 0x6e, 0x65, 0x77, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1e, 0x6e, 0x65, 0x77, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x23, 0x0a, 0x21, 0x54, 0x65, 0x72, 0x6d, 0x69,
+	0x6e, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x16, 0x0a, 0x14,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x71, 0x0a, 0x17, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2e, 0x0a, 0x12, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3c, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x0f, 0x0a, 0x0d, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb9, 0x0e, 0x0a, 0x0f, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x47, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6f, 0x0a, 0x05, 0x4c, 0x6f,
+	0x67, 0x69, 0x6e, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22, 0x1a, 0x2f, 0x76,
+	0x31, 0x2f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x2f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x70, 0x0a, 0x06, 0x4c,
+	0x6f, 0x67, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22, 0x1a,
+	0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x8c, 0x01,
+	0x0a, 0x0a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x27, 0x2e, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x2b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25, 0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x2d, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x8d, 0x01, 0x0a,
+	0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x12, 0x27, 0x2e, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x2b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25, 0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x2d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x90, 0x01, 0x0a,
+	0x16, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0xa0, 0x01, 0x0a, 0x19, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2e, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x12, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x64, 0x12, 0x83, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x2e, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12,
+	0x17, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x5c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31, 0x2f,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0xa7, 0x01, 0x0a, 0x21, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x36, 0x2e, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x1a, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2d, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2d, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x3a, 0x01, 0x2a,
+	0x12, 0x95, 0x01, 0x0a, 0x1a, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x47, 0x61,
+	0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x2f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x29, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x23, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x2d,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2f, 0x74,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x69, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x45, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x22, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x45, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x11, 0x22, 0x0f, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x2f,
+	0x65, 0x6e, 0x64, 0x12, 0x72, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x25, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x6f,
+	0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x2d,
+	0x64, 0x61, 0x74, 0x61, 0x3a, 0x01, 0x2a, 0x12, 0x75, 0x0a, 0x0e, 0x50, 0x75, 0x73, 0x68, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x25, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x19, 0x22, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x2d, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x70, 0x75, 0x73, 0x68, 0x3a, 0x01, 0x2a, 0x12, 0x72,
+	0x0a, 0x0f, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x24, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x1a, 0x11, 0x2f, 0x76,
+	0x31, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x3a,
+	0x01, 0x2a, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x3b, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x73, 0x64,
+	0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_local_grpc_service_proto_rawDescOnce sync.Once
+	file_local_grpc_service_proto_rawDescData = file_local_grpc_service_proto_rawDesc
+)
+
+func file_local_grpc_service_proto_rawDescGZIP() []byte {
+	file_local_grpc_service_proto_rawDescOnce.Do(func() {
+		file_local_grpc_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_local_grpc_service_proto_rawDescData)
+	})
+	return file_local_grpc_service_proto_rawDescData
+}
+
+var file_local_grpc_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_local_grpc_service_proto_goTypes = []interface{}{
+	(*PlayerSessionRequest)(nil),                     // 0: localService.PlayerSessionRequest
+	(*BatchPlayerSessionRequest)(nil),                // 1: localService.BatchPlayerSessionRequest
+	(*PlayerSessionResult)(nil),                      // 2: localService.PlayerSessionResult
+	(*BatchPlayerSessionResponse)(nil),               // 3: localService.BatchPlayerSessionResponse
+	(*DescribePlayerSessionsRequest)(nil),            // 4: localService.DescribePlayerSessionsRequest
+	(*PlayerSession)(nil),                            // 5: localService.PlayerSession
+	(*DescribePlayerSessionsResponse)(nil),           // 6: localService.DescribePlayerSessionsResponse
+	(*QueryCachedPlayerSessionsRequest)(nil),         // 7: localService.QueryCachedPlayerSessionsRequest
+	(*CachedPlayerSession)(nil),                      // 8: localService.CachedPlayerSession
+	(*QueryCachedPlayerSessionsResponse)(nil),        // 9: localService.QueryCachedPlayerSessionsResponse
+	(*GetGameServerSessionRequest)(nil),              // 10: localService.GetGameServerSessionRequest
+	(*GameProperty)(nil),                             // 11: localService.GameProperty
+	(*GameServerSession)(nil),                        // 12: localService.GameServerSession
+	(*GetStatusRequest)(nil),                         // 13: localService.GetStatusRequest
+	(*WrapperStatus)(nil),                            // 14: localService.WrapperStatus
+	(*UpdatePlayerSessionCreationPolicyRequest)(nil), // 15: localService.UpdatePlayerSessionCreationPolicyRequest
+	(*TerminateGameServerSessionRequest)(nil),        // 16: localService.TerminateGameServerSessionRequest
+	(*ProcessEndingRequest)(nil),                     // 17: localService.ProcessEndingRequest
+	(*ReportCustomDataRequest)(nil),                  // 18: localService.ReportCustomDataRequest
+	(*SetHealthStatusRequest)(nil),                   // 19: localService.SetHealthStatusRequest
+	(*LocalResponse)(nil),                            // 20: localService.LocalResponse
+}
+var file_local_grpc_service_proto_depIdxs = []int32{
+	2,  // 0: localService.BatchPlayerSessionResponse.results:type_name -> localService.PlayerSessionResult
+	5,  // 1: localService.DescribePlayerSessionsResponse.playerSessions:type_name -> localService.PlayerSession
+	8,  // 2: localService.QueryCachedPlayerSessionsResponse.playerSessions:type_name -> localService.CachedPlayerSession
+	11, // 3: localService.GameServerSession.gameProperties:type_name -> localService.GameProperty
+	0,  // 4: localService.LocalGseService.Login:input_type -> localService.PlayerSessionRequest
+	0,  // 5: localService.LocalGseService.Logout:input_type -> localService.PlayerSessionRequest
+	1,  // 6: localService.LocalGseService.BatchLogin:input_type -> localService.BatchPlayerSessionRequest
+	1,  // 7: localService.LocalGseService.BatchLogout:input_type -> localService.BatchPlayerSessionRequest
+	4,  // 8: localService.LocalGseService.DescribePlayerSessions:input_type -> localService.DescribePlayerSessionsRequest
+	7,  // 9: localService.LocalGseService.QueryCachedPlayerSessions:input_type -> localService.QueryCachedPlayerSessionsRequest
+	10, // 10: localService.LocalGseService.GetGameServerSession:input_type -> localService.GetGameServerSessionRequest
+	13, // 11: localService.LocalGseService.GetStatus:input_type -> localService.GetStatusRequest
+	15, // 12: localService.LocalGseService.UpdatePlayerSessionCreationPolicy:input_type -> localService.UpdatePlayerSessionCreationPolicyRequest
+	16, // 13: localService.LocalGseService.TerminateGameServerSession:input_type -> localService.TerminateGameServerSessionRequest
+	17, // 14: localService.LocalGseService.ProcessEnding:input_type -> localService.ProcessEndingRequest
+	18, // 15: localService.LocalGseService.ReportCustomData:input_type -> localService.ReportCustomDataRequest
+	18, // 16: localService.LocalGseService.PushCustomData:input_type -> localService.ReportCustomDataRequest
+	19, // 17: localService.LocalGseService.SetHealthStatus:input_type -> localService.SetHealthStatusRequest
+	20, // 18: localService.LocalGseService.Login:output_type -> localService.LocalResponse
+	20, // 19: localService.LocalGseService.Logout:output_type -> localService.LocalResponse
+	3,  // 20: localService.LocalGseService.BatchLogin:output_type -> localService.BatchPlayerSessionResponse
+	3,  // 21: localService.LocalGseService.BatchLogout:output_type -> localService.BatchPlayerSessionResponse
+	6,  // 22: localService.LocalGseService.DescribePlayerSessions:output_type -> localService.DescribePlayerSessionsResponse
+	9,  // 23: localService.LocalGseService.QueryCachedPlayerSessions:output_type -> localService.QueryCachedPlayerSessionsResponse
+	12, // 24: localService.LocalGseService.GetGameServerSession:output_type -> localService.GameServerSession
+	14, // 25: localService.LocalGseService.GetStatus:output_type -> localService.WrapperStatus
+	20, // 26: localService.LocalGseService.UpdatePlayerSessionCreationPolicy:output_type -> localService.LocalResponse
+	20, // 27: localService.LocalGseService.TerminateGameServerSession:output_type -> localService.LocalResponse
+	20, // 28: localService.LocalGseService.ProcessEnding:output_type -> localService.LocalResponse
+	20, // 29: localService.LocalGseService.ReportCustomData:output_type -> localService.LocalResponse
+	20, // 30: localService.LocalGseService.PushCustomData:output_type -> localService.LocalResponse
+	20, // 31: localService.LocalGseService.SetHealthStatus:output_type -> localService.LocalResponse
+	18, // [18:32] is the sub-list for method output_type
+	4,  // [4:18] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_local_grpc_service_proto_init() }
+func file_local_grpc_service_proto_init() {
+	if File_local_grpc_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_local_grpc_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchPlayerSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerSessionResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchPlayerSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DescribePlayerSessionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DescribePlayerSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryCachedPlayerSessionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CachedPlayerSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryCachedPlayerSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGameServerSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameProperty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameServerSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WrapperStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdatePlayerSessionCreationPolicyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TerminateGameServerSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessEndingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportCustomDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetHealthStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_local_grpc_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_local_grpc_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_local_grpc_service_proto_goTypes,
+		DependencyIndexes: file_local_grpc_service_proto_depIdxs,
+		MessageInfos:      file_local_grpc_service_proto_msgTypes,
+	}.Build()
+	File_local_grpc_service_proto = out.File
+	file_local_grpc_service_proto_rawDesc = nil
+	file_local_grpc_service_proto_goTypes = nil
+	file_local_grpc_service_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// LocalGseServiceClient is the client API for LocalGseService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LocalGseServiceClient interface {
+	Login(ctx context.Context, in *PlayerSessionRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+	Logout(ctx context.Context, in *PlayerSessionRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+	// 批量接受/移除玩家会话，每个 ID 单独返回结果
+	BatchLogin(ctx context.Context, in *BatchPlayerSessionRequest, opts ...grpc.CallOption) (*BatchPlayerSessionResponse, error)
+	BatchLogout(ctx context.Context, in *BatchPlayerSessionRequest, opts ...grpc.CallOption) (*BatchPlayerSessionResponse, error)
+	DescribePlayerSessions(ctx context.Context, in *DescribePlayerSessionsRequest, opts ...grpc.CallOption) (*DescribePlayerSessionsResponse, error)
+	// 从 wrapper 本地缓存查询玩家会话，不调用 agent
+	QueryCachedPlayerSessions(ctx context.Context, in *QueryCachedPlayerSessionsRequest, opts ...grpc.CallOption) (*QueryCachedPlayerSessionsResponse, error)
+	// OnStartGameServerSession 下发的当前游戏会话
+	GetGameServerSession(ctx context.Context, in *GetGameServerSessionRequest, opts ...grpc.CallOption) (*GameServerSession, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*WrapperStatus, error)
+	UpdatePlayerSessionCreationPolicy(ctx context.Context, in *UpdatePlayerSessionCreationPolicyRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+	TerminateGameServerSession(ctx context.Context, in *TerminateGameServerSessionRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+	ProcessEnding(ctx context.Context, in *ProcessEndingRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+	ReportCustomData(ctx context.Context, in *ReportCustomDataRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+	// 交给自动上报：值有变化且超过最小间隔才调用 agent
+	PushCustomData(ctx context.Context, in *ReportCustomDataRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+	SetHealthStatus(ctx context.Context, in *SetHealthStatusRequest, opts ...grpc.CallOption) (*LocalResponse, error)
+}
+
+type localGseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLocalGseServiceClient(cc grpc.ClientConnInterface) LocalGseServiceClient {
+	return &localGseServiceClient{cc}
+}
+
+func (c *localGseServiceClient) Login(ctx context.Context, in *PlayerSessionRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) Logout(ctx context.Context, in *PlayerSessionRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) BatchLogin(ctx context.Context, in *BatchPlayerSessionRequest, opts ...grpc.CallOption) (*BatchPlayerSessionResponse, error) {
+	out := new(BatchPlayerSessionResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/BatchLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) BatchLogout(ctx context.Context, in *BatchPlayerSessionRequest, opts ...grpc.CallOption) (*BatchPlayerSessionResponse, error) {
+	out := new(BatchPlayerSessionResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/BatchLogout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) DescribePlayerSessions(ctx context.Context, in *DescribePlayerSessionsRequest, opts ...grpc.CallOption) (*DescribePlayerSessionsResponse, error) {
+	out := new(DescribePlayerSessionsResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/DescribePlayerSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) QueryCachedPlayerSessions(ctx context.Context, in *QueryCachedPlayerSessionsRequest, opts ...grpc.CallOption) (*QueryCachedPlayerSessionsResponse, error) {
+	out := new(QueryCachedPlayerSessionsResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/QueryCachedPlayerSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) GetGameServerSession(ctx context.Context, in *GetGameServerSessionRequest, opts ...grpc.CallOption) (*GameServerSession, error) {
+	out := new(GameServerSession)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/GetGameServerSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*WrapperStatus, error) {
+	out := new(WrapperStatus)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) UpdatePlayerSessionCreationPolicy(ctx context.Context, in *UpdatePlayerSessionCreationPolicyRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/UpdatePlayerSessionCreationPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) TerminateGameServerSession(ctx context.Context, in *TerminateGameServerSessionRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/TerminateGameServerSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) ProcessEnding(ctx context.Context, in *ProcessEndingRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/ProcessEnding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) ReportCustomData(ctx context.Context, in *ReportCustomDataRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/ReportCustomData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) PushCustomData(ctx context.Context, in *ReportCustomDataRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/PushCustomData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localGseServiceClient) SetHealthStatus(ctx context.Context, in *SetHealthStatusRequest, opts ...grpc.CallOption) (*LocalResponse, error) {
+	out := new(LocalResponse)
+	err := c.cc.Invoke(ctx, "/localService.LocalGseService/SetHealthStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LocalGseServiceServer is the server API for LocalGseService service.
+type LocalGseServiceServer interface {
+	Login(context.Context, *PlayerSessionRequest) (*LocalResponse, error)
+	Logout(context.Context, *PlayerSessionRequest) (*LocalResponse, error)
+	// 批量接受/移除玩家会话，每个 ID 单独返回结果
+	BatchLogin(context.Context, *BatchPlayerSessionRequest) (*BatchPlayerSessionResponse, error)
+	BatchLogout(context.Context, *BatchPlayerSessionRequest) (*BatchPlayerSessionResponse, error)
+	DescribePlayerSessions(context.Context, *DescribePlayerSessionsRequest) (*DescribePlayerSessionsResponse, error)
+	// 从 wrapper 本地缓存查询玩家会话，不调用 agent
+	QueryCachedPlayerSessions(context.Context, *QueryCachedPlayerSessionsRequest) (*QueryCachedPlayerSessionsResponse, error)
+	// OnStartGameServerSession 下发的当前游戏会话
+	GetGameServerSession(context.Context, *GetGameServerSessionRequest) (*GameServerSession, error)
+	GetStatus(context.Context, *GetStatusRequest) (*WrapperStatus, error)
+	UpdatePlayerSessionCreationPolicy(context.Context, *UpdatePlayerSessionCreationPolicyRequest) (*LocalResponse, error)
+	TerminateGameServerSession(context.Context, *TerminateGameServerSessionRequest) (*LocalResponse, error)
+	ProcessEnding(context.Context, *ProcessEndingRequest) (*LocalResponse, error)
+	ReportCustomData(context.Context, *ReportCustomDataRequest) (*LocalResponse, error)
+	// 交给自动上报：值有变化且超过最小间隔才调用 agent
+	PushCustomData(context.Context, *ReportCustomDataRequest) (*LocalResponse, error)
+	SetHealthStatus(context.Context, *SetHealthStatusRequest) (*LocalResponse, error)
+}
+
+// UnimplementedLocalGseServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedLocalGseServiceServer struct {
+}
+
+func (*UnimplementedLocalGseServiceServer) Login(context.Context, *PlayerSessionRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) Logout(context.Context, *PlayerSessionRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) BatchLogin(context.Context, *BatchPlayerSessionRequest) (*BatchPlayerSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchLogin not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) BatchLogout(context.Context, *BatchPlayerSessionRequest) (*BatchPlayerSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchLogout not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) DescribePlayerSessions(context.Context, *DescribePlayerSessionsRequest) (*DescribePlayerSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribePlayerSessions not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) QueryCachedPlayerSessions(context.Context, *QueryCachedPlayerSessionsRequest) (*QueryCachedPlayerSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryCachedPlayerSessions not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) GetGameServerSession(context.Context, *GetGameServerSessionRequest) (*GameServerSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGameServerSession not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) GetStatus(context.Context, *GetStatusRequest) (*WrapperStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) UpdatePlayerSessionCreationPolicy(context.Context, *UpdatePlayerSessionCreationPolicyRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePlayerSessionCreationPolicy not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) TerminateGameServerSession(context.Context, *TerminateGameServerSessionRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateGameServerSession not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) ProcessEnding(context.Context, *ProcessEndingRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessEnding not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) ReportCustomData(context.Context, *ReportCustomDataRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCustomData not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) PushCustomData(context.Context, *ReportCustomDataRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushCustomData not implemented")
+}
+func (*UnimplementedLocalGseServiceServer) SetHealthStatus(context.Context, *SetHealthStatusRequest) (*LocalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHealthStatus not implemented")
+}
+
+func RegisterLocalGseServiceServer(s *grpc.Server, srv LocalGseServiceServer) {
+	s.RegisterService(&_LocalGseService_serviceDesc, srv)
+}
+
+func _LocalGseService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).Login(ctx, req.(*PlayerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).Logout(ctx, req.(*PlayerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_BatchLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchPlayerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).BatchLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/BatchLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).BatchLogin(ctx, req.(*BatchPlayerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_BatchLogout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchPlayerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).BatchLogout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/BatchLogout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).BatchLogout(ctx, req.(*BatchPlayerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_DescribePlayerSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribePlayerSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).DescribePlayerSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/DescribePlayerSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).DescribePlayerSessions(ctx, req.(*DescribePlayerSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_QueryCachedPlayerSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryCachedPlayerSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).QueryCachedPlayerSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/QueryCachedPlayerSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).QueryCachedPlayerSessions(ctx, req.(*QueryCachedPlayerSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_GetGameServerSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameServerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).GetGameServerSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/GetGameServerSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).GetGameServerSession(ctx, req.(*GetGameServerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_UpdatePlayerSessionCreationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePlayerSessionCreationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).UpdatePlayerSessionCreationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/UpdatePlayerSessionCreationPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).UpdatePlayerSessionCreationPolicy(ctx, req.(*UpdatePlayerSessionCreationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_TerminateGameServerSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminateGameServerSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).TerminateGameServerSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/TerminateGameServerSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).TerminateGameServerSession(ctx, req.(*TerminateGameServerSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_ProcessEnding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessEndingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).ProcessEnding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/ProcessEnding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).ProcessEnding(ctx, req.(*ProcessEndingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_ReportCustomData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCustomDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).ReportCustomData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/ReportCustomData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).ReportCustomData(ctx, req.(*ReportCustomDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_PushCustomData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCustomDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).PushCustomData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/PushCustomData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).PushCustomData(ctx, req.(*ReportCustomDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalGseService_SetHealthStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHealthStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalGseServiceServer).SetHealthStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localService.LocalGseService/SetHealthStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalGseServiceServer).SetHealthStatus(ctx, req.(*SetHealthStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _LocalGseService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "localService.LocalGseService",
+	HandlerType: (*LocalGseServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _LocalGseService_Login_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _LocalGseService_Logout_Handler,
+		},
+		{
+			MethodName: "BatchLogin",
+			Handler:    _LocalGseService_BatchLogin_Handler,
+		},
+		{
+			MethodName: "BatchLogout",
+			Handler:    _LocalGseService_BatchLogout_Handler,
+		},
+		{
+			MethodName: "DescribePlayerSessions",
+			Handler:    _LocalGseService_DescribePlayerSessions_Handler,
+		},
+		{
+			MethodName: "QueryCachedPlayerSessions",
+			Handler:    _LocalGseService_QueryCachedPlayerSessions_Handler,
+		},
+		{
+			MethodName: "GetGameServerSession",
+			Handler:    _LocalGseService_GetGameServerSession_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _LocalGseService_GetStatus_Handler,
+		},
+		{
+			MethodName: "UpdatePlayerSessionCreationPolicy",
+			Handler:    _LocalGseService_UpdatePlayerSessionCreationPolicy_Handler,
+		},
+		{
+			MethodName: "TerminateGameServerSession",
+			Handler:    _LocalGseService_TerminateGameServerSession_Handler,
+		},
+		{
+			MethodName: "ProcessEnding",
+			Handler:    _LocalGseService_ProcessEnding_Handler,
+		},
+		{
+			MethodName: "ReportCustomData",
+			Handler:    _LocalGseService_ReportCustomData_Handler,
+		},
+		{
+			MethodName: "PushCustomData",
+			Handler:    _LocalGseService_PushCustomData_Handler,
+		},
+		{
+			MethodName: "SetHealthStatus",
+			Handler:    _LocalGseService_SetHealthStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "local_grpc_service.proto",
+}
@@ -15,30 +15,39 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
+	"os/signal"
 	"path"
 	"regexp"
 	"strconv"
 	"strings"
+	"supertuxkart/agones"
 	"supertuxkart/api"
+	"supertuxkart/events"
 	"supertuxkart/gsemanager"
 	"supertuxkart/logger"
+	"supertuxkart/portalloc"
+	"supertuxkart/tracing"
+	"sync"
+	"syscall"
 	"time"
 
-	"github.com/hpcloud/tail"
-	"math/rand"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // logLocation is the path to the location of the SuperTuxKart log file
 const logLocation = "/.config/supertuxkart/config-0.10/server_config.log"
 
-func startGrpcServer() int {
-	// 启动grpc server，监听agent回调
+func startGrpcServer(index int) int {
+	// 启动grpc server，监听agent回调；多进程时每个游戏进程一个
 	grpcServer := api.GetRpcService()
+	if index > 0 {
+		grpcServer = api.NewRpcService()
+	}
 	grpcServer.StartGrpcServer()
 	grpcPort := grpcServer.GetGrpcPort()
 
@@ -46,99 +55,397 @@ func startGrpcServer() int {
 	return grpcPort
 }
 
+func startHttpServer(listenAddr string, auth bool, scopes []string, tokenFile string, standalone bool,
+	probeAddr string) (int, []string) {
+	// 启动http server，供游戏进程调用 gse 接口
+	httpServer := api.NewHttpProcess(listenAddr)
+	if standalone {
+		httpServer.EnableStandalone()
+	}
+	if auth {
+		if err := httpServer.EnableTokenAuth(scopes, tokenFile); err != nil {
+			log.Fatalf("error enabling http auth: %v", err)
+		}
+	}
+	httpServer.StartHttpServer()
+	if probeAddr != "" {
+		if err := httpServer.StartProbeServer(probeAddr); err != nil {
+			log.Fatalf("error starting probe server: %v", err)
+		}
+	}
+
+	// 返回 http port 和传给游戏进程的环境变量
+	return httpServer.GetHttpPort(), httpServer.ChildEnv()
+}
+
 // main intercepts the log file of the SuperTuxKart gameserver and uses it
 // to determine if the game server is ready or not.
 func main() {
-	// 启动Grpc Server
-	grpcPort := startGrpcServer()
-
-	// 随机端口
-	rand.Seed(time.Now().Unix())
-	clientPort := 20000 + rand.Intn(10000)
-
 	log.SetPrefix("[wrapper] ")
 	input := flag.String("i", "", "the command and arguments to execute the server binary")
+	backendFlag := flag.String("backend", gsemanager.BackendGse, "the game server SDK the wrapper drives: gse or agones")
+	agonesHealthInterval := flag.Duration("agones-health-interval", 2*time.Second,
+		"how often a healthy server pings the Agones sidecar, used with -backend=agones")
+	standalone := flag.Bool("standalone", false,
+		"If true, run without any agent: platform calls are served in memory and sessions are started over http")
+	processes := flag.Int("processes", 1, "how many game server processes this wrapper runs, each with its own port")
 
 	// Since player tracking is not on by default, it is behind this flag.
 	// If it is off, still log messages about players, but don't actually call the player tracking functions.
 	enablePlayerTracking := flag.Bool("player-tracking", false, "If true, player tracking will be enabled.")
-	flag.Parse()
 
-	log.Println("Starting wrapper for SuperTuxKart")
+	reconcileInterval := flag.Duration("player-session-reconcile-interval", 30*time.Second,
+		"how often the local player session cache is reconciled with the agent, 0 to disable")
+
+	maxPlayers := flag.Int("max-players", 0,
+		"caps the players per session below the session's MaxPlayers, 0 for no extra cap")
+	stkMaxPlayers := flag.Bool("stk-max-players", false,
+		"If true, also pass -max-players to SuperTuxKart as --max-players")
+
+	reservationTimeout := flag.Duration("player-session-reservation-timeout", 0,
+		"remove accepted player sessions whose player has not joined the game within this time, 0 to disable")
+
+	clientPortFlag := flag.Int("port", 0, "the game port, 0 to take it from -port-env or allocate one from -port-range")
+	portEnv := flag.String("port-env", "GSE_GAME_PORT", "the environment variable holding a port assigned by the fleet")
+	portRange := flag.String("port-range", portalloc.DefaultRange, "the range game ports are allocated from")
+	launchAttempts := flag.Int("launch-attempts", 3, "how many ports to try when the game fails to bind its port")
+	launchTimeoutFlag := flag.Duration("launch-timeout", time.Minute,
+		"how long to wait for the game to listen before calling ProcessReady anyway")
+
+	autoPolicy := flag.Bool("auto-player-session-policy", true,
+		"If true, switch the player session creation policy to DENY_ALL while the session is full or draining")
+
+	customDataSource := flag.String("custom-data-source", gsemanager.CustomDataSourceNone,
+		"where ReportCustomData values come from: log (peer count), file or push, empty to disable")
+	customDataFile := flag.String("custom-data-file", "/local/game/custom_data.json",
+		"the JSON stats file written by the game, used with -custom-data-source=file")
+	customDataInterval := flag.Duration("custom-data-interval", 5*time.Second,
+		"the minimum time between two automatic ReportCustomData calls, also the stats file poll interval")
+	customDataMax := flag.Int("custom-data-max", 0,
+		"maxCustomCount reported with -custom-data-source=log, 0 to use the session's player cap")
 
-	cmdString := strings.Split(*input, " ")
-	cmdString = append(cmdString, "--port="+strconv.Itoa(clientPort))
-	command, args := cmdString[0], cmdString[1:]
+	activationReadyTimeout := flag.Duration("activation-ready-timeout", 10*time.Second,
+		"how long OnStartGameServerSession waits for the game to listen before failing with Unavailable, 0 to not wait")
+	activationCheck := flag.String("activation-check", "",
+		"an executable run with the game server session JSON on stdin before activation; a non-zero exit vetoes it")
+	activationCheckTimeout := flag.Duration("activation-check-timeout", 5*time.Second, "the time limit of -activation-check")
+	grpcReflection := flag.Bool("grpc-reflection", false, "register grpc server reflection, for debugging with grpcurl")
+	grpcStopTimeout := flag.Duration("grpc-stop-timeout", 5*time.Second,
+		"the time in-flight grpc calls get to finish when a process's termination ends or the wrapper exits")
+	terminateGrace := flag.Duration("terminate-grace", 30*time.Second,
+		"the time allowed to shut down after OnProcessTerminate when its terminationTime is missing or already passed")
+	terminateEndReserve := flag.Duration("terminate-end-reserve", 10*time.Second,
+		"the time kept before the termination deadline to end the session and the process; waiting for players to leave stops then")
 
-	log.Printf("Command being run for SuperTuxKart server: %s \n", cmdString)
+	httpAddr := flag.String("http-addr", "127.0.0.1:", "the address the local http api listens on")
+	httpAuth := flag.Bool("http-auth", false,
+		"If true, the http api and LocalGseService on GSE_GRPC_PORT require the bearer token handed to the game")
+	httpTokenScopes := flag.String("http-token-scopes", "read,player,lifecycle", "comma separated scopes granted to the game's http token")
+	httpTokenFile := flag.String("http-token-file", "/local/game/gse_http_token.json", "where to write the generated http tokens, empty to skip")
+	probeAddr := flag.String("probe-addr", "",
+		"an extra address serving only /healthz and /readyz, without token auth, e.g. :8086 for kubelet; empty for none")
 
-	cmd := exec.Command(command, args...) // #nosec
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
+	eventsHistory := flag.Int("events-history", events.DefaultHistorySize, "how many recent events /gse/events replays to new clients")
+	hooksConfig := flag.String("hooks-config", "", "the JSON file declaring event webhooks and hook scripts, empty for none")
 
-	if err := cmd.Start(); err != nil {
-		log.Fatalf("error starting cmd: %v", err)
+	traceExporter := flag.String("trace-exporter", tracing.ExporterNone, "where to export spans: none, otlp or file")
+	traceEndpoint := flag.String("trace-endpoint", "127.0.0.1:4317", "the OTLP grpc collector address, used with -trace-exporter=otlp")
+	traceFile := flag.String("trace-file", "/local/game/log/trace.json", "the span output file, used with -trace-exporter=file")
+	flag.Parse()
+
+	if _, err := gsemanager.ParseCustomDataSource(*customDataSource); err != nil {
+		log.Fatalf("error parsing -custom-data-source: %v", err)
+	}
+	if _, err := gsemanager.ParseBackend(*backendFlag); err != nil {
+		log.Fatalf("error parsing -backend: %v", err)
+	}
+	// 一个 Agones GameServer 只对应一个游戏进程
+	if *backendFlag == gsemanager.BackendAgones && *processes > 1 {
+		log.Fatalf("-backend=agones supports a single process")
+	}
+	if *standalone && *backendFlag != gsemanager.BackendGse {
+		log.Fatalf("-standalone cannot be used with -backend=%s", *backendFlag)
+	}
+	// 单机模式没有 agent，由本地内存实现；Agones 由 sidecar 实现，没有的玩家会话等接口也在本地内存里
+	switch {
+	case *standalone:
+		gsemanager.SetSdkFactory(func(pid string) gsemanager.Sdk { return gsemanager.NewLocalSdk(pid) })
+	case *backendFlag == gsemanager.BackendAgones:
+		gsemanager.SetSdkFactory(func(pid string) gsemanager.Sdk {
+			return gsemanager.NewAgonesSdk(pid, agones.NewClientFromEnv(), *enablePlayerTracking,
+				api.GetRpcService().HealthStatus, *agonesHealthInterval)
+		})
+	}
+	if *processes < 1 {
+		log.Fatalf("-processes must be at least 1")
+	}
+	// custom data 只有一个 reporter，对应第一个进程
+	if *processes > 1 && *customDataSource != gsemanager.CustomDataSourceNone {
+		log.Fatalf("-custom-data-source is not supported with -processes > 1")
 	}
 
-	log.Printf("Connecting to Gse with the SDK, pid: %d \n", cmd.Process.Pid)
-	gseManager := gsemanager.GetGseManagerByPid(cmd.Process.Pid)
-	err := gseManager.ProcessReady([]string{"/local/game/log/log.txt"}, int32(clientPort), int32(grpcPort))
-	if err != nil {
-		logger.Fatal("ProcessReady fail")
+	if err := tracing.Init(*traceExporter, *traceEndpoint, *traceFile); err != nil {
+		log.Fatalf("error initializing tracing: %v", err)
+	}
+
+	api.SetActivationReadyTimeout(*activationReadyTimeout)
+	api.SetGrpcReflection(*grpcReflection)
+	api.SetGrpcStopTimeout(*grpcStopTimeout)
+	api.SetTerminationOptions(gsemanager.TerminationOptions{Grace: *terminateGrace, EndReserve: *terminateEndReserve})
+	if *activationCheck != "" {
+		if _, err := os.Stat(*activationCheck); err != nil {
+			log.Fatalf("error reading -activation-check: %v", err)
+		}
+		api.SetActivationCheck(api.ScriptActivationCheck(*activationCheck, *activationCheckTimeout))
 	}
 
-	log.Println("Gse connected")
+	events.SetHistorySize(*eventsHistory)
+	if *hooksConfig != "" {
+		config, err := events.LoadHookConfig(*hooksConfig)
+		if err != nil {
+			log.Fatalf("error loading hooks config: %v", err)
+		}
+		if err := events.StartHooks(config); err != nil {
+			log.Fatalf("error starting hooks: %v", err)
+		}
+	}
+
+	httpPort, httpEnv := startHttpServer(*httpAddr, *httpAuth, strings.Split(*httpTokenScopes, ","), *httpTokenFile,
+		*standalone, *probeAddr)
+
+	log.Println("Starting wrapper for SuperTuxKart")
 
-	// SuperTuxKart refuses to output to foreground, so we're going to
-	// poll the server log.
 	home, err := os.UserHomeDir()
 	if err != nil {
 		log.Fatalf("could not get home dir: %v", err)
 	}
 
-	log.Printf("SuperTuxKart log file path: %s \n", path.Join(home, logLocation))
+	explicitPort := *clientPortFlag
+	if explicitPort == 0 {
+		if explicitPort, err = portalloc.FromEnv(*portEnv); err != nil {
+			log.Fatalf("error reading -port-env: %v", err)
+		}
+	}
+	if explicitPort != 0 && *processes > 1 {
+		log.Fatalf("a fixed game port cannot be shared by %d processes", *processes)
+	}
+	ports, err := portalloc.New(*portRange, explicitPort, os.Getpid())
+	if err != nil {
+		log.Fatalf("error creating port allocator: %v", err)
+	}
 
-	t := &tail.Tail{}
-	// Loop to make sure the log has been created. Sometimes it takes a few seconds
-	for i := 0; i < 10; i++ {
-		time.Sleep(time.Second)
+	var extraArgs []string
+	if *stkMaxPlayers && *maxPlayers > 0 {
+		extraArgs = append(extraArgs, "--max-players="+strconv.Itoa(*maxPlayers))
+	}
 
-		t, err = tail.TailFile(path.Join(home, logLocation), tail.Config{Follow: true})
-		if err != nil {
-			log.Print(err)
-			continue
-		} else {
-			break
-		}
-	}
-	defer t.Cleanup()
-	for line := range t.Lines {
-		// Don't use the logger here. This would add multiple prefixes to the logs. We just want
-		// to show the supertuxkart logs as they are, and layer the wrapper logs in with them.
-		fmt.Println(line.Text)
-		action, player := handleLogLine(line.Text)
-		switch action {
-		case "READY":
-			log.Print("log to mark server ready")
-		case "PLAYERJOIN":
-			log.Printf("Player Join: %s \n", player)
-			if player == nil {
-				log.Print("could not determine player")
-				break
+	customDataReporter := gsemanager.GetCustomDataReporter()
+	customDataReporter.Start(*customDataSource, *customDataInterval)
+	if *customDataSource == gsemanager.CustomDataSourceFile {
+		customDataReporter.WatchFile(*customDataFile, *customDataInterval)
+	}
+
+	// runProcess 启动第 index 个游戏进程并处理它的日志，ProcessReady 之后关闭 ready，日志结束时返回
+	runProcess := func(index int, ready chan<- struct{}) {
+		grpcPort := startGrpcServer(index)
+
+		// 游戏进程通过这两个端口访问 http 接口和本地 grpc 接口
+		env := append(os.Environ(), "GSE_GRPC_PORT="+strconv.Itoa(grpcPort))
+		env = append(env, httpEnv...)
+
+		// 其它进程用单独的配置目录，日志不会互相覆盖
+		logPath := path.Join(home, logLocation)
+		logPaths := []string{"/local/game/log/log.txt"}
+		if index > 0 {
+			configHome := path.Join(home, ".config", "process-"+strconv.Itoa(index))
+			env = append(env, "XDG_CONFIG_HOME="+configHome)
+			logPath = path.Join(configHome, strings.TrimPrefix(logLocation, "/.config"))
+		}
+		if *processes > 1 {
+			logPaths = append(logPaths, logPath)
+		}
+		log.Printf("SuperTuxKart log file path: %s \n", logPath)
+
+		// 端口被占用时换一个端口重新启动
+		var stk *game
+		var readyLine string
+		for attempt := 1; ; attempt++ {
+			clientPort, err := ports.Next()
+			if err != nil {
+				log.Fatalf("error allocating game port: %v", err)
+			}
+
+			stk, err = launchGame(*input, clientPort, extraArgs, env, logPath)
+			if err != nil {
+				log.Fatalf("error starting cmd: %v", err)
+			}
+
+			result, line, err := stk.waitForListening(*launchTimeoutFlag)
+			if err != nil {
+				log.Fatalf("error launching game: %v", err)
 			}
-			if *enablePlayerTracking {
-				log.Print("enablePlayerTracking")
+			if result == launchTimeout {
+				log.Printf("game did not report listening within %s, continuing", *launchTimeoutFlag)
 			}
-		case "PLAYERLEAVE":
-			log.Printf("Player Join: %s \n", player)
-			if player == nil {
-				log.Print("could not determine player")
+			if result != launchBindFail {
+				readyLine = line
 				break
 			}
-		case "SHUTDOWN":
-			log.Print("No more players, maybe shutdown")
-			// os.Exit(0)
+
+			stk.stop()
+			ports.Failed(clientPort)
+			if attempt >= *launchAttempts {
+				log.Fatalf("game failed to bind a port after %d attempts", attempt)
+			}
+			log.Printf("game failed to bind port %d, relaunching", clientPort)
+		}
+		cmd, clientPort, t := stk.cmd, stk.port, stk.tail
+
+		log.Printf("Connecting to Gse with the SDK, pid: %d \n", cmd.Process.Pid)
+		gseManager := gsemanager.NewGseManager(cmd.Process.Pid)
+		gseManager.SetHttpPort(httpPort)
+
+		err := gseManager.ProcessReady(logPaths, int32(clientPort), int32(grpcPort))
+		if err != nil {
+			logger.Fatal("ProcessReady fail")
+		}
+
+		log.Println("Gse connected")
+		events.Publish(events.Event{Type: events.ProcessReady, GrpcPort: grpcPort})
+		if index == 0 {
+			customDataReporter.Attach(gseManager)
+		}
+		close(ready)
+
+		gseManager.SetMaxPlayers(*maxPlayers)
+		gseManager.SetAutoPlayerSessionPolicy(*autoPolicy)
+
+		if *reconcileInterval > 0 {
+			gseManager.StartPlayerSessionReconcile(*reconcileInterval)
+		}
+		if *reservationTimeout > 0 {
+			gseManager.StartReservationExpiry(*reservationTimeout)
 		}
+
+		// 单机模式没有 agent 结束进程，ProcessEnding 之后自己停止游戏
+		if *standalone {
+			var stopOnce sync.Once
+			events.Handle("standalone-"+strconv.Itoa(index), func(events.Event) {
+				if gseManager.State() == gsemanager.StateEnded {
+					stopOnce.Do(func() {
+						log.Printf("process %d ended, stopping the game", index)
+						stk.stop()
+					})
+				}
+			}, events.ProcessEnded)
+		}
+
+		defer t.Cleanup()
+		handleLine := func(text string) {
+			if *customDataSource == gsemanager.CustomDataSourceLog {
+				if peers, ok := parsePeerCount(text); ok {
+					customDataReporter.Update(gsemanager.CustomData{
+						CurrentCustomCount: int32(peers),
+						MaxCustomCount:     int32(*customDataMax),
+					})
+				}
+			}
+			action, player := handleLogLine(text)
+			switch action {
+			case "READY":
+				log.Print("log to mark server ready")
+				gseManager.Trace().Event("stk.ready")
+				gseManager.AdvanceState(gsemanager.StateServerReady)
+				events.Publish(events.Event{Type: events.ServerReady, GrpcPort: grpcPort,
+					GameServerSessionId: gseManager.GameServerSessionId()})
+			case "PLAYERJOIN":
+				if player == nil {
+					log.Print("could not determine player")
+					break
+				}
+				log.Printf("Player Join: %s \n", *player)
+				gseManager.Trace().Event("stk.player_join", attribute.String("player", *player))
+				gseManager.PlayerConnected(*player)
+				events.Publish(events.Event{Type: events.PlayerJoin, GrpcPort: grpcPort,
+					GameServerSessionId: gseManager.GameServerSessionId(), PlayerId: *player})
+				if *enablePlayerTracking {
+					log.Print("enablePlayerTracking")
+				}
+			case "PLAYERLEAVE":
+				if player == nil {
+					log.Print("could not determine player")
+					break
+				}
+				log.Printf("Player Leave: %s \n", *player)
+				gseManager.Trace().Event("stk.player_leave", attribute.String("player", *player))
+				gseManager.PlayerDisconnected(*player)
+				events.Publish(events.Event{Type: events.PlayerLeave, GrpcPort: grpcPort,
+					GameServerSessionId: gseManager.GameServerSessionId(), PlayerId: *player})
+			case "SHUTDOWN":
+				log.Print("No more players, maybe shutdown")
+				gseManager.Trace().Event("stk.no_players")
+				events.Publish(events.Event{Type: events.NoPlayers, GrpcPort: grpcPort,
+					GameServerSessionId: gseManager.GameServerSessionId()})
+				// os.Exit(0)
+			case "BINDFAIL":
+				log.Print("game failed to bind its port after launch")
+			}
+		}
+
+		if readyLine != "" {
+			handleLine(readyLine)
+		}
+		for line := range t.Lines {
+			// Don't use the logger here. This would add multiple prefixes to the logs. We just want
+			// to show the supertuxkart logs as they are, and layer the wrapper logs in with them.
+			fmt.Println(line.Text)
+			handleLine(line.Text)
+		}
+		log.Printf("tail of process %d ended", index)
+		if err := gseManager.Shutdown(); err != nil {
+			log.Printf("error shutting down process %d: %v", index, err)
+		}
+	}
+
+	var shutdownOnce sync.Once
+	shutdown := func() {
+		shutdownOnce.Do(func() {
+			api.StopGrpcServers(*grpcStopTimeout)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tracing.Shutdown(ctx); err != nil {
+				log.Printf("error flushing spans: %v", err)
+			}
+		})
+	}
+
+	// GSE 模式下日志不会结束，收到 SIGTERM/SIGINT 时也要停止 grpc server 并导出 span
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		sig := <-signals
+		log.Printf("received %v, shutting down", sig)
+		shutdown()
+		os.Exit(0)
+	}()
+
+	// 逐个启动，上一个进程 ProcessReady 之后再启动下一个
+	var wg sync.WaitGroup
+	for i := 0; i < *processes; i++ {
+		ready := make(chan struct{})
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			runProcess(index, ready)
+		}(i)
+		<-ready
+	}
+	wg.Wait()
+
+	shutdown()
+	if *standalone {
+		log.Println("all processes ended")
+		return
 	}
 	log.Fatal("tail ended")
 }
@@ -151,6 +458,7 @@ func handleLogLine(line string) (string, *string) {
 	playerLeave := regexp.MustCompile(`ServerLobby: (.+) disconnected$`)
 	noMorePlayers := regexp.MustCompile(`STKHost.+There are now 0 peers\.$`)
 	serverStart := regexp.MustCompile(`Listening has been started`)
+	bindFail := regexp.MustCompile(`(?i)(error occurred while trying to create an ENet server host|failed to bind|address already in use)`)
 
 	// Start the server
 	if serverStart.MatchString(line) {
@@ -158,6 +466,12 @@ func handleLogLine(line string) (string, *string) {
 		return "READY", nil
 	}
 
+	// The port is taken, the wrapper relaunches on another one
+	if bindFail.MatchString(line) {
+		log.Print("server failed to bind its port")
+		return "BINDFAIL", nil
+	}
+
 	// Player tracking
 	if playerJoin.MatchString(line) {
 		matches := playerJoin.FindSubmatch([]byte(line))
@@ -179,3 +493,19 @@ func handleLogLine(line string) (string, *string) {
 	}
 	return "", nil
 }
+
+// parsePeerCount returns N from the "There are now N peers." lines STKHost
+// logs whenever a peer connects or leaves.
+func parsePeerCount(line string) (int, bool) {
+	peerCount := regexp.MustCompile(`STKHost.+There are now ([0-9]+) peers\.$`)
+
+	matches := peerCount.FindStringSubmatch(line)
+	if matches == nil {
+		return 0, false
+	}
+	peers, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, false
+	}
+	return peers, true
+}
@@ -0,0 +1,143 @@
+package portalloc
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultRange 默认的游戏端口范围
+const DefaultRange = "20000-29999"
+
+// Allocator hands out game ports that are free for both UDP and TCP. With an
+// explicit port it only ever returns that port; otherwise it walks the range
+// from an offset derived from the seed, so two wrappers on one host start at
+// different ports and the same wrapper always starts at the same one.
+type Allocator struct {
+	mu       sync.Mutex
+	min, max int
+	explicit int
+	seed     int
+	next     int
+	failed   map[int]bool
+}
+
+// New returns an allocator for portRange ("min-max"), or for explicit when it
+// is not 0. seed is usually the wrapper's pid.
+func New(portRange string, explicit int, seed int) (*Allocator, error) {
+	a := &Allocator{
+		explicit: explicit,
+		seed:     seed,
+		failed:   make(map[int]bool),
+	}
+
+	if explicit != 0 {
+		if explicit < 1 || explicit > 65535 {
+			return nil, fmt.Errorf("port %d out of range", explicit)
+		}
+		return a, nil
+	}
+
+	var err error
+	if a.min, a.max, err = parseRange(portRange); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// FromEnv returns the port in environment variable name, or 0 when it is
+// unset or empty.
+func FromEnv(name string) (int, error) {
+	value := os.Getenv(name)
+	if name == "" || value == "" {
+		return 0, nil
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s=%q is not a port", name, value)
+	}
+	return port, nil
+}
+
+func parseRange(portRange string) (int, int, error) {
+	parts := strings.SplitN(portRange, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("port range %q, expect min-max", portRange)
+	}
+
+	min, errMin := strconv.Atoi(strings.TrimSpace(parts[0]))
+	max, errMax := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if errMin != nil || errMax != nil || min < 1 || max > 65535 || min > max {
+		return 0, 0, fmt.Errorf("port range %q, expect min-max within 1-65535", portRange)
+	}
+	return min, max, nil
+}
+
+func (a *Allocator) start() int {
+	h := fnv.New32a()
+	h.Write([]byte(strconv.Itoa(a.seed)))
+	return int(h.Sum32() % uint32(a.max-a.min+1))
+}
+
+// Next returns the next free port that has not been marked Failed.
+func (a *Allocator) Next() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.explicit != 0 {
+		if a.failed[a.explicit] {
+			return 0, fmt.Errorf("port %d failed and no other port is allowed", a.explicit)
+		}
+		if err := Probe(a.explicit); err != nil {
+			return 0, err
+		}
+		return a.explicit, nil
+	}
+
+	size := a.max - a.min + 1
+	offset := a.start()
+	for i := 0; i < size; i++ {
+		port := a.min + (offset+a.next+i)%size
+		if a.failed[port] {
+			continue
+		}
+		if Probe(port) != nil {
+			continue
+		}
+		a.next += i + 1
+		return port, nil
+	}
+	return 0, fmt.Errorf("no free port in %d-%d", a.min, a.max)
+}
+
+// Failed marks port as unusable, e.g. after the game failed to bind it.
+func (a *Allocator) Failed(port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.failed[port] = true
+}
+
+// Probe returns an error unless port can be bound for both UDP and TCP on
+// all interfaces.
+func Probe(port int) error {
+	addr := ":" + strconv.Itoa(port)
+
+	udp, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return fmt.Errorf("udp port %d in use: %v", port, err)
+	}
+	udp.Close()
+
+	tcp, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("tcp port %d in use: %v", port, err)
+	}
+	tcp.Close()
+	return nil
+}
@@ -0,0 +1,127 @@
+package portalloc
+
+import (
+	"net"
+	"strconv"
+	"testing"
+)
+
+// testRange 测试用的端口段，一般没有进程占用
+const testRange = "41200-41207"
+
+func TestNewRejectsBadInput(t *testing.T) {
+	for _, portRange := range []string{"", "20000", "a-b", "0-10", "10-70000", "30000-20000"} {
+		if _, err := New(portRange, 0, 1); err == nil {
+			t.Errorf("New(%q) succeeded", portRange)
+		}
+	}
+	for _, explicit := range []int{-1, 65536} {
+		if _, err := New(DefaultRange, explicit, 1); err == nil {
+			t.Errorf("New with explicit port %d succeeded", explicit)
+		}
+	}
+}
+
+func TestNextIsStablePerSeed(t *testing.T) {
+	first := func(seed int) int {
+		a, err := New(testRange, 0, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		port, err := a.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return port
+	}
+
+	if a, b := first(42), first(42); a != b {
+		t.Errorf("same seed started at %d and %d", a, b)
+	}
+}
+
+func TestNextWalksTheRange(t *testing.T) {
+	a, err := New(testRange, 0, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[int]bool)
+	for i := 0; i < 8; i++ {
+		port, err := a.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port < 41200 || port > 41207 {
+			t.Fatalf("port %d outside %s", port, testRange)
+		}
+		if seen[port] {
+			t.Errorf("port %d handed out twice", port)
+		}
+		seen[port] = true
+	}
+}
+
+func TestNextSkipsFailedAndBusyPorts(t *testing.T) {
+	a, _ := New(testRange, 0, 7)
+	failed, _ := a.Next()
+
+	a, _ = New(testRange, 0, 7)
+	a.Failed(failed)
+	busy, err := a.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if busy == failed {
+		t.Fatalf("Next returned failed port %d", failed)
+	}
+
+	l, err := net.Listen("tcp", ":"+strconv.Itoa(busy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	a, _ = New(testRange, 0, 7)
+	a.Failed(failed)
+	if port, err := a.Next(); err != nil || port == busy || port == failed {
+		t.Errorf("Next = %d, %v; want a port other than failed %d and busy %d", port, err, failed, busy)
+	}
+}
+
+func TestExplicitPort(t *testing.T) {
+	a, err := New(DefaultRange, 41210, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if port, err := a.Next(); err != nil || port != 41210 {
+			t.Errorf("Next = %d, %v; want 41210", port, err)
+		}
+	}
+
+	a.Failed(41210)
+	if _, err := a.Next(); err == nil {
+		t.Error("Next succeeded after the explicit port failed")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("PORTALLOC_TEST_PORT", "7777")
+	if port, err := FromEnv("PORTALLOC_TEST_PORT"); err != nil || port != 7777 {
+		t.Errorf("FromEnv = %d, %v; want 7777", port, err)
+	}
+
+	t.Setenv("PORTALLOC_TEST_PORT", "")
+	if port, err := FromEnv("PORTALLOC_TEST_PORT"); err != nil || port != 0 {
+		t.Errorf("FromEnv of an empty variable = %d, %v; want 0", port, err)
+	}
+	if port, err := FromEnv(""); err != nil || port != 0 {
+		t.Errorf("FromEnv without a name = %d, %v; want 0", port, err)
+	}
+
+	t.Setenv("PORTALLOC_TEST_PORT", "http")
+	if _, err := FromEnv("PORTALLOC_TEST_PORT"); err == nil {
+		t.Error("FromEnv accepted a non-numeric port")
+	}
+}
@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"os"
+	"supertuxkart/logger"
+	"sync"
+)
+
+const (
+	ExporterNone = "none"
+	ExporterOtlp = "otlp"
+	ExporterFile = "file"
+
+	serviceName = "supertuxkart-wrapper"
+)
+
+var (
+	provider *sdktrace.TracerProvider
+	tracer   = otel.Tracer("supertuxkart")
+
+	// ExporterFile 写的文件，provider 关闭、span 写完之后才能关
+	traceFile *os.File
+
+	// 所有进程的会话 span，Shutdown 时一起结束
+	sessionsMu sync.Mutex
+	sessions   []*Session
+)
+
+// Session 持有一个游戏进程当前游戏会话的 span，日志事件和对 agent 的调用都挂在它下面，
+// 这样可以直接看出从 ActivateGameServerSession 到第一个玩家加入的耗时
+type Session struct {
+	mu   sync.Mutex
+	ctx  context.Context
+	span trace.Span
+}
+
+// NewSession returns the session span holder of one game server process.
+func NewSession() *Session {
+	s := &Session{ctx: context.Background()}
+
+	sessionsMu.Lock()
+	sessions = append(sessions, s)
+	sessionsMu.Unlock()
+	return s
+}
+
+// Init installs the global tracer provider. exporter is one of ExporterNone,
+// ExporterOtlp (endpoint is the collector's grpc address) or ExporterFile
+// (spans are written as JSON lines to filePath).
+func Init(exporter, endpoint, filePath string) error {
+	var spanExporter sdktrace.SpanExporter
+	var err error
+
+	switch exporter {
+	case "", ExporterNone:
+		return nil
+	case ExporterOtlp:
+		spanExporter, err = otlptracegrpc.New(context.Background(),
+			otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	case ExporterFile:
+		var file *os.File
+		file, err = os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err == nil {
+			if spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(file)); err != nil {
+				file.Close()
+			} else {
+				traceFile = file
+			}
+		}
+	default:
+		return fmt.Errorf("unknown trace exporter %q", exporter)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String(serviceName),
+		attribute.Int("process.pid", os.Getpid()))
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Info("tracing enabled", zap.String("exporter", exporter), zap.String("endpoint", endpoint),
+		zap.String("file", filePath))
+	return nil
+}
+
+// Shutdown ends the open session spans, flushes buffered spans and closes
+// the trace file of ExporterFile. It is safe to call when tracing is
+// disabled.
+func Shutdown(ctx context.Context) error {
+	sessionsMu.Lock()
+	open := append([]*Session(nil), sessions...)
+	sessionsMu.Unlock()
+	for _, s := range open {
+		s.End()
+	}
+
+	if provider == nil {
+		return nil
+	}
+
+	err := provider.Shutdown(ctx)
+	if traceFile != nil {
+		if closeErr := traceFile.Close(); err == nil {
+			err = closeErr
+		}
+		traceFile = nil
+	}
+	return err
+}
+
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Start opens the long-lived span covering a game server session, ending
+// the previous one of this process.
+func (s *Session) Start(gameServerSessionId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.span != nil {
+		s.span.End()
+	}
+	s.ctx, s.span = tracer.Start(context.Background(), "GameServerSession",
+		trace.WithAttributes(attribute.String("gameServerSessionId", gameServerSessionId)))
+}
+
+func (s *Session) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.span != nil {
+		s.span.End()
+	}
+	s.ctx, s.span = context.Background(), nil
+}
+
+// Context returns the context of the current session span, or
+// context.Background() when no session is active.
+func (s *Session) Context() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ctx
+}
+
+// Event records a log-derived event (server ready, player join/leave) as a
+// zero-length span under the current session.
+func (s *Session) Event(name string, attrs ...attribute.KeyValue) {
+	_, span := Start(s.Context(), name, attrs...)
+	span.End()
+}
+
+// UnaryClientInterceptor wraps every outbound call to the agent in a span.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := Start(ctx, method, attribute.String("rpc.system", "grpc"))
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			span.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+		}
+		End(span, err)
+		return err
+	}
+}
@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShutdownFlushesTheTraceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+	if err := Init(ExporterFile, "", path); err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := Start(context.Background(), "OnHealthCheck")
+	End(span, nil)
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if traceFile != nil {
+		t.Error("the trace file is still open after Shutdown")
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"Name":"OnHealthCheck"`) {
+		t.Errorf("trace file = %q, want the OnHealthCheck span", b)
+	}
+}